@@ -0,0 +1,68 @@
+package wallhavenapi
+
+import (
+	"context"
+	"slices"
+	"strings"
+)
+
+// UseAccountDefaults fetches the account's settings and returns a copy of the
+// client whose queries default to them, so searches match what the user sees
+// on the website. It requires a client with an API key. See WithUserSettings
+// for how the settings are mapped.
+func (wh *WallhavenAPI) UseAccountDefaults(ctx context.Context) (*WallhavenAPI, error) {
+	settings, err := wh.UserSettingsContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return wh.WithUserSettings(settings), nil
+}
+
+// WithUserSettings returns a copy of the client whose queries default to the
+// purity, categories, resolutions and aspect ratios in settings. The toplist
+// range becomes the default for toplist queries only, as with WithRange.
+// Empty settings leave the client's defaults alone, and values the client
+// does not understand are skipped.
+//
+// The results per page are not set here: Wallhaven applies the account's
+// page size itself to requests made with its API key.
+func (wh *WallhavenAPI) WithUserSettings(settings UserSettings) *WallhavenAPI {
+	derived := wh.derive(func(q *Query) {
+		if purity, err := ParsePurity(strings.Join(settings.Purity, ",")); err == nil && purity != 0 {
+			q.Purity(purity)
+		}
+		if categories, err := ParseCategory(strings.Join(settings.Categories, ",")); err == nil && categories != 0 {
+			q.Categories(categories)
+		}
+
+		var resolutions []Resolution
+		for _, value := range settings.Resolutions {
+			if res, err := ParseResolution(value); err == nil {
+				resolutions = append(resolutions, res)
+			}
+		}
+		if len(resolutions) > 0 {
+			q.Resolutions(resolutions...)
+		}
+
+		var ratios []RatioFilter
+		for _, value := range settings.AspectRatios {
+			switch group := RatioGroup(strings.ToLower(value)); group {
+			case Landscape, Portrait:
+				ratios = append(ratios, group)
+				continue
+			}
+			if ratio, err := ParseAspectRatio(value); err == nil {
+				ratios = append(ratios, ratio)
+			}
+		}
+		if len(ratios) > 0 {
+			q.Ratios(ratios...)
+		}
+	})
+
+	if rng := RangeType(settings.ToplistRange); slices.Contains(validRanges, rng) {
+		derived.toplistRange = rng
+	}
+	return derived
+}
@@ -0,0 +1,146 @@
+package wallhavenapi
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/davenicholson-xyz/go-wallhaven/wallhavenapi/fetch"
+)
+
+// BatchError reports the wallpapers that failed in an operation on many
+// wallpapers, keyed by wallpaper ID, or for Wallpapers by the reference as
+// passed in. errors.Is and errors.As look through
+// every failure, so errors.Is(err, ErrNotFound) reports whether any ID was missing.
+type BatchError struct {
+	Errors map[string]error
+}
+
+func (e *BatchError) Error() string {
+	ids := slices.Sorted(maps.Keys(e.Errors))
+	if len(ids) == 1 {
+		return fmt.Sprintf("wallpaper %s: %v", ids[0], e.Errors[ids[0]])
+	}
+	return fmt.Sprintf("%d wallpapers failed, first %s: %v", len(ids), ids[0], e.Errors[ids[0]])
+}
+
+// Unwrap returns the individual failures, ordered by wallpaper ID.
+func (e *BatchError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Errors))
+	for _, id := range slices.Sorted(maps.Keys(e.Errors)) {
+		errs = append(errs, e.Errors[id])
+	}
+	return errs
+}
+
+// Wallpapers looks up many wallpapers at once, fetching up to the client's
+// worker count concurrently (see WithWorkers) within its rate limit. Each
+// reference may be an ID or any form accepted by ParseWallpaperRef, and
+// references to the same wallpaper are fetched only once.
+//
+// The results are in the order of refs, with a zero Wallpaper for each that
+// failed. The failures are returned as a *BatchError keyed by reference;
+// references not looked up before ctx is done fail with ctx's error.
+// Unless ctx carries its own priority, lookups run at PriorityNormal so that
+// single Wallpaper calls are not stuck behind a large batch.
+func (wh *WallhavenAPI) Wallpapers(ctx context.Context, refs ...string) ([]Wallpaper, error) {
+	if !fetch.HasPriority(ctx) {
+		ctx = fetch.WithPriority(ctx, PriorityNormal)
+	}
+
+	errs := make(map[string]error)
+	ids := make([]string, len(refs))
+	var valid []string
+	for i, ref := range refs {
+		id, err := ParseWallpaperRef(ref)
+		if err != nil {
+			errs[ref] = err
+			continue
+		}
+		ids[i] = id
+		valid = append(valid, id)
+	}
+
+	found, failed := wh.fetchMany(ctx, valid, wh.WallpaperContext)
+
+	results := make([]Wallpaper, len(refs))
+	for i, ref := range refs {
+		if wallpaper, ok := found[ids[i]]; ok {
+			results[i] = wallpaper
+		} else if err, ok := failed[ids[i]]; ok {
+			errs[ref] = err
+		}
+	}
+	if len(errs) > 0 {
+		return results, &BatchError{Errors: errs}
+	}
+	return results, nil
+}
+
+// WithWorkers returns a copy of the client that fetches up to n wallpapers at
+// once in Wallpapers, HydrateAll and WithDetails, for a single batch that
+// needs more or less parallelism than the client was created with. The copy
+// shares the client's rate limiter. Values below 1 are treated as 1.
+func (wh *WallhavenAPI) WithWorkers(n int) *WallhavenAPI {
+	derived := wh.derive(nil)
+	derived.workers = max(n, 1)
+	return derived
+}
+
+// fetchMany calls fetch once for each distinct ID, on up to the client's
+// worker count of goroutines. IDs not started before ctx is done fail with
+// ctx's error. Requests still go through the client's rate limiter.
+func (wh *WallhavenAPI) fetchMany(ctx context.Context, ids []string, fetch func(context.Context, string) (Wallpaper, error)) (map[string]Wallpaper, map[string]error) {
+	var unique []string
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		results = make(map[string]Wallpaper, len(unique))
+		errs    = make(map[string]error)
+		jobs    = make(chan string)
+	)
+	for range min(max(wh.workers, 1), len(unique)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for id := range jobs {
+				wallpaper, err := fetch(ctx, id)
+				mu.Lock()
+				if err != nil {
+					errs[id] = err
+				} else {
+					results[id] = wallpaper
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+feed:
+	for i, id := range unique {
+		select {
+		case jobs <- id:
+		case <-ctx.Done():
+			mu.Lock()
+			for _, skipped := range unique[i:] {
+				errs[skipped] = ctx.Err()
+			}
+			mu.Unlock()
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results, errs
+}
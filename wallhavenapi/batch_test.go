@@ -0,0 +1,74 @@
+package wallhavenapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// concurrencyServer serves /w/{id} slowly and records the most requests in
+// flight at once.
+func concurrencyServer(t *testing.T) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var inFlight, peak atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"data":{"id":%q,"purity":"sfw","category":"general"}}`, strings.TrimPrefix(r.URL.Path, "/w/"))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &peak
+}
+
+func TestWallpapersWorkers(t *testing.T) {
+	refs := make([]string, 12)
+	for i := range refs {
+		refs[i] = fmt.Sprintf("aaaa%02d", i)
+	}
+
+	tests := []struct {
+		name     string
+		client   func(*WallhavenAPI) *WallhavenAPI
+		wantPeak int32 // most requests allowed in flight
+	}{
+		{"option", func(wh *WallhavenAPI) *WallhavenAPI { return wh }, 2},
+		{"more for one call", func(wh *WallhavenAPI) *WallhavenAPI { return wh.WithWorkers(6) }, 6},
+		{"fewer for one call", func(wh *WallhavenAPI) *WallhavenAPI { return wh.WithWorkers(1) }, 1},
+		{"below one", func(wh *WallhavenAPI) *WallhavenAPI { return wh.WithWorkers(-3) }, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, peak := concurrencyServer(t)
+			wh := New(WithBaseURL(srv.URL), WithoutRateLimit(), WithoutRetry(), WithWorkers(2))
+
+			wallpapers, err := tt.client(wh).Wallpapers(context.Background(), refs...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i, w := range wallpapers {
+				if w.ID != refs[i] {
+					t.Errorf("wallpapers[%d].ID = %q, want %q", i, w.ID, refs[i])
+				}
+			}
+			if got := peak.Load(); got > tt.wantPeak || (tt.wantPeak > 1 && got < 2) {
+				t.Errorf("peak concurrency = %d, want up to %d", got, tt.wantPeak)
+			}
+			if wh.workers != 2 {
+				t.Errorf("parent client workers = %d, want 2", wh.workers)
+			}
+		})
+	}
+}
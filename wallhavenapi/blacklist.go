@@ -0,0 +1,120 @@
+package wallhavenapi
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Blacklist lists tags and uploaders whose wallpapers are dropped from results.
+// Names are matched case-insensitively; tags match by name or any of their aliases.
+//
+// Search and collection listings from Wallhaven do not include tags or the
+// uploader, so a client with a blacklist fetches full details for every
+// result of its queries, see WithBlacklist.
+type Blacklist struct {
+	Tags  []string `json:"tags,omitempty"`
+	Users []string `json:"users,omitempty"`
+}
+
+// BlacklistFromSettings returns the tag and user blacklists of an account.
+func BlacklistFromSettings(settings UserSettings) Blacklist {
+	return Blacklist{Tags: settings.TagBlacklist, Users: settings.UserBlacklist}
+}
+
+// Blocks reports whether w is from a blacklisted uploader or has a blacklisted tag.
+func (b Blacklist) Blocks(w Wallpaper) bool {
+	if w.Uploader.Username != "" && containsFold(b.Users, w.Uploader.Username) {
+		return true
+	}
+	return slices.ContainsFunc(w.Tags, func(tag Tag) bool {
+		if containsFold(b.Tags, tag.Name) {
+			return true
+		}
+		for _, alias := range strings.Split(tag.Alias, ",") {
+			if alias = strings.TrimSpace(alias); alias != "" && containsFold(b.Tags, alias) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// IsEmpty reports whether the blacklist blocks nothing.
+func (b Blacklist) IsEmpty() bool {
+	return len(b.Tags) == 0 && len(b.Users) == 0
+}
+
+// merge returns the union of both blacklists.
+func (b Blacklist) merge(other Blacklist) Blacklist {
+	union := func(a, b []string) []string {
+		out := slices.Clone(a)
+		for _, name := range b {
+			if name = strings.TrimSpace(name); name != "" && !containsFold(out, name) {
+				out = append(out, name)
+			}
+		}
+		return out
+	}
+	return Blacklist{Tags: union(b.Tags, other.Tags), Users: union(b.Users, other.Users)}
+}
+
+// WithBlacklist returns a copy of the client that drops wallpapers matching
+// the blacklist from every result: search and collection pages and single
+// wallpaper lookups. It adds to any blacklist the client already has.
+// Page.Dropped reports how many wallpapers were removed from a page.
+//
+// As listings lack tags and uploaders, queries on the returned client fetch
+// every result's details as if WithDetails was used, one request per
+// wallpaper within the rate limit. A wallpaper whose details cannot be
+// fetched is left out of the page rather than let through unchecked; its
+// error, wrapping ErrBlacklistUnchecked, is reported in Page.Errors.
+func (wh *WallhavenAPI) WithBlacklist(blacklist Blacklist) *WallhavenAPI {
+	derived := wh.derive(nil)
+	derived.blacklist = wh.blacklist.merge(blacklist)
+	return derived
+}
+
+// UseAccountBlacklist fetches the account's tag and user blacklists and returns
+// a copy of the client that enforces them, in addition to its own blacklist.
+// It requires a client with an API key.
+func (wh *WallhavenAPI) UseAccountBlacklist(ctx context.Context) (*WallhavenAPI, error) {
+	settings, err := wh.UserSettingsContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return wh.WithBlacklist(BlacklistFromSettings(settings)), nil
+}
+
+// Blacklist returns the blacklist the client enforces.
+func (wh *WallhavenAPI) Blacklist() Blacklist {
+	return wh.blacklist.merge(Blacklist{})
+}
+
+// filterWallpapers removes the wallpapers the client's result filters reject
+// and returns the rest together with the number removed.
+func (wh *WallhavenAPI) filterWallpapers(items []Wallpaper) ([]Wallpaper, int) {
+	if wh.blacklist.IsEmpty() && wh.safeMode == nil {
+		return items, 0
+	}
+	before := len(items)
+	items = slices.DeleteFunc(items, func(w Wallpaper) bool { return wh.checkWallpaper(w) != nil })
+	return items, before - len(items)
+}
+
+// checkWallpaper returns an error wrapping ErrSafeMode or ErrBlacklisted if
+// the client's result filters reject w.
+func (wh *WallhavenAPI) checkWallpaper(w Wallpaper) error {
+	if err := wh.safeMode.checkWallpaper(w); err != nil {
+		return err
+	}
+	if wh.blacklist.Blocks(w) {
+		return fmt.Errorf("%w: wallpaper %s", ErrBlacklisted, w.ID)
+	}
+	return nil
+}
+
+func containsFold(names []string, name string) bool {
+	return slices.ContainsFunc(names, func(n string) bool { return strings.EqualFold(n, name) })
+}
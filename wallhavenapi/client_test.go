@@ -0,0 +1,119 @@
+package wallhavenapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const testSeed = "Xk8sPq"
+
+// newTestServer serves canned responses for the endpoints exercised below.
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/tag/"):
+			fmt.Fprint(w, `{"data":{"id":1,"name":"anime","alias":"","category_id":1,"category":"Anime & Manga","purity":"sfw","created_at":"2015-01-01 00:00:00"}}`)
+		case strings.HasPrefix(r.URL.Path, "/w/"):
+			fmt.Fprintf(w, `{"data":{"id":%q,"purity":"sfw","category":"general"}}`, strings.TrimPrefix(r.URL.Path, "/w/"))
+		case r.URL.Path == "/search":
+			page := r.URL.Query().Get("page")
+			if page == "" {
+				page = "1"
+			}
+			seed := "null"
+			if r.URL.Query().Get("sorting") == string(Random) {
+				seed = `"` + testSeed + `"`
+			}
+			fmt.Fprintf(w, `{"data":[{"id":"abc123","purity":"sfw","category":"general"}],"meta":{"current_page":%s,"last_page":5,"per_page":24,"total":120,"query":null,"seed":%s}}`, page, seed)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// TestClientConcurrentUse shares one client and one query between goroutines;
+// run it with -race to check that derived clients and queries don't race.
+func TestClientConcurrentUse(t *testing.T) {
+	srv := newTestServer(t)
+	wh := New(WithBaseURL(srv.URL), WithoutRateLimit(), WithoutRetry())
+	before := wh.urlbuilder.Build()
+
+	random := wh.Search("nature").Sort(Random)
+
+	const workers = 8
+	var wg sync.WaitGroup
+	errs := make(chan error, workers*5)
+	for i := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx := context.Background()
+
+			if _, err := wh.WithPurity(SFW, Sketchy).TopList().Get(); err != nil {
+				errs <- fmt.Errorf("TopList: %w", err)
+			}
+			if _, err := wh.WithAPIKey(fmt.Sprintf("key-%d", i)).Hot().Get(); err != nil {
+				errs <- fmt.Errorf("Hot: %w", err)
+			}
+			if _, err := wh.Tag(1); err != nil {
+				errs <- fmt.Errorf("Tag: %w", err)
+			}
+			if _, err := random.PageContext(ctx, i%5+1); err != nil {
+				errs <- fmt.Errorf("PageContext: %w", err)
+			}
+			if seed := random.CurrentSeed(); seed != "" && seed != testSeed {
+				errs <- fmt.Errorf("CurrentSeed = %q, want %q", seed, testSeed)
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+
+	if after := wh.urlbuilder.Build(); after != before {
+		t.Errorf("parent client URL changed from %q to %q", before, after)
+	}
+	if wh.hasAPIKey() {
+		t.Error("parent client gained an API key from WithAPIKey")
+	}
+	if seed := random.CurrentSeed(); seed != testSeed {
+		t.Errorf("CurrentSeed = %q after fetching, want %q", seed, testSeed)
+	}
+}
+
+// TestAllRangedConcurrently ranges over one All sequence from several goroutines.
+func TestAllRangedConcurrently(t *testing.T) {
+	srv := newTestServer(t)
+	seq := New(WithBaseURL(srv.URL), WithoutRateLimit(), WithoutRetry()).Search("nature").Limit(3).All(context.Background())
+
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			n := 0
+			for _, err := range seq {
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				n++
+			}
+			if n != 3 {
+				t.Errorf("yielded %d wallpapers, want 3", n)
+			}
+		}()
+	}
+	wg.Wait()
+}
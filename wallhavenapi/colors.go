@@ -0,0 +1,143 @@
+package wallhavenapi
+
+import (
+	"image/color"
+	"math"
+	"strconv"
+)
+
+// Color is one of the colours Wallhaven can search by, written as a lowercase
+// hex string without the "#" prefix. Color implements color.Color.
+type Color string
+
+// The colours of Wallhaven's search palette.
+const (
+	ColorMaroon       Color = "660000"
+	ColorDarkRed      Color = "990000"
+	ColorRed          Color = "cc0000"
+	ColorBrickRed     Color = "cc3333"
+	ColorPink         Color = "ea4c88"
+	ColorPurple       Color = "993399"
+	ColorViolet       Color = "663399"
+	ColorIndigo       Color = "333399"
+	ColorBlue         Color = "0066cc"
+	ColorCerulean     Color = "0099cc"
+	ColorTurquoise    Color = "66cccc"
+	ColorLime         Color = "77cc33"
+	ColorGreen        Color = "669900"
+	ColorDarkGreen    Color = "336600"
+	ColorOlive        Color = "666600"
+	ColorDarkYellow   Color = "999900"
+	ColorYellowGreen  Color = "cccc33"
+	ColorYellow       Color = "ffff00"
+	ColorGold         Color = "ffcc33"
+	ColorOrange       Color = "ff9900"
+	ColorDarkOrange   Color = "ff6600"
+	ColorRust         Color = "cc6633"
+	ColorBrown        Color = "996633"
+	ColorDarkBrown    Color = "663300"
+	ColorBlack        Color = "000000"
+	ColorGrey         Color = "999999"
+	ColorLightGrey    Color = "cccccc"
+	ColorWhite        Color = "ffffff"
+	ColorCharcoalBlue Color = "424153"
+)
+
+// Palette lists every colour Wallhaven can search by, in the order the site shows them.
+var Palette = []Color{
+	ColorMaroon, ColorDarkRed, ColorRed, ColorBrickRed, ColorPink, ColorPurple,
+	ColorViolet, ColorIndigo, ColorBlue, ColorCerulean, ColorTurquoise, ColorLime,
+	ColorGreen, ColorDarkGreen, ColorOlive, ColorDarkYellow, ColorYellowGreen, ColorYellow,
+	ColorGold, ColorOrange, ColorDarkOrange, ColorRust, ColorBrown, ColorDarkBrown,
+	ColorBlack, ColorGrey, ColorLightGrey, ColorWhite, ColorCharcoalBlue,
+}
+
+// RGBA implements color.Color. A Color that is not valid hex is treated as opaque black.
+func (c Color) RGBA() (r, g, b, a uint32) {
+	v, err := strconv.ParseUint(string(c), 16, 32)
+	if err != nil || len(c) != 6 {
+		v = 0
+	}
+	rgba := color.RGBA{R: uint8(v >> 16), G: uint8(v >> 8), B: uint8(v), A: 0xff}
+	return rgba.RGBA()
+}
+
+func (c Color) String() string {
+	return string(c)
+}
+
+// InPalette reports whether c is one of the colours Wallhaven can search by.
+func (c Color) InPalette() bool {
+	for _, p := range Palette {
+		if c == p {
+			return true
+		}
+	}
+	return false
+}
+
+// NearestColor returns the palette colour perceptually closest to c,
+// measured as the Euclidean distance between the two in CIE L*a*b* space.
+func NearestColor(c color.Color) Color {
+	target := toLab(c)
+	nearest := Palette[0]
+	best := math.Inf(1)
+	for _, p := range Palette {
+		if d := target.distance(toLab(p)); d < best {
+			best = d
+			nearest = p
+		}
+	}
+	return nearest
+}
+
+// WithColor returns a copy of the client whose queries default to the palette
+// colour nearest to c. Palette constants are used unchanged.
+func (wh *WallhavenAPI) WithColor(c color.Color) *WallhavenAPI {
+	return wh.derive(func(q *Query) { q.Color(c) })
+}
+
+// Color filters wallpapers by dominant color inline, accepting any color.Color.
+// Palette constants such as ColorBlue are used as they are; any other colour is
+// mapped to the nearest palette colour, since Wallhaven only searches by those.
+func (q *Query) Color(c color.Color) *Query {
+	return q.Colors(string(NearestColor(c)))
+}
+
+type lab struct {
+	l, a, b float64
+}
+
+func (x lab) distance(y lab) float64 {
+	return math.Sqrt((x.l-y.l)*(x.l-y.l) + (x.a-y.a)*(x.a-y.a) + (x.b-y.b)*(x.b-y.b))
+}
+
+// toLab converts an sRGB colour to CIE L*a*b* using the D65 white point.
+func toLab(c color.Color) lab {
+	r16, g16, b16, _ := c.RGBA()
+	r := linearize(float64(r16) / 0xffff)
+	g := linearize(float64(g16) / 0xffff)
+	b := linearize(float64(b16) / 0xffff)
+
+	x := (0.4124*r + 0.3576*g + 0.1805*b) / 0.95047
+	y := 0.2126*r + 0.7152*g + 0.0722*b
+	z := (0.0193*r + 0.1192*g + 0.9505*b) / 1.08883
+
+	fx, fy, fz := labF(x), labF(y), labF(z)
+	return lab{l: 116*fy - 16, a: 500 * (fx - fy), b: 200 * (fy - fz)}
+}
+
+func linearize(v float64) float64 {
+	if v <= 0.04045 {
+		return v / 12.92
+	}
+	return math.Pow((v+0.055)/1.055, 2.4)
+}
+
+func labF(t float64) float64 {
+	const delta = 6.0 / 29.0
+	if t > delta*delta*delta {
+		return math.Cbrt(t)
+	}
+	return t/(3*delta*delta) + 4.0/29.0
+}
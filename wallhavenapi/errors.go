@@ -0,0 +1,53 @@
+package wallhavenapi
+
+import (
+	"errors"
+
+	"github.com/davenicholson-xyz/go-wallhaven/wallhavenapi/fetch"
+)
+
+// APIError is returned when Wallhaven responds with an error status or a non-JSON body.
+// It carries the status code, endpoint path, the message from Wallhaven's
+// {"error": ...} body and a truncated copy of the raw body.
+type APIError = fetch.APIError
+
+// Sentinel errors for use with errors.Is.
+var (
+	ErrNotFound     = fetch.ErrNotFound
+	ErrUnauthorized = fetch.ErrUnauthorized
+	ErrRateLimited  = fetch.ErrRateLimited
+	ErrServer       = fetch.ErrServer
+	ErrNotJSON      = fetch.ErrNotJSON
+	ErrCircuitOpen  = fetch.ErrCircuitOpen
+
+	// ErrAPIKeyRequired is returned by endpoints that need an API key when none is set.
+	ErrAPIKeyRequired = errors.New("API key required")
+
+	// ErrInvalidQuery is wrapped by every *ParamError reported by Query.Validate.
+	ErrInvalidQuery = errors.New("invalid query parameter")
+
+	// ErrInvalidSeed is returned when a random seed is not 6 letters or digits.
+	ErrInvalidSeed = errors.New("invalid seed")
+
+	// ErrUnsupportedURL is returned by ParseWebURL for links it cannot turn into a query.
+	ErrUnsupportedURL = errors.New("unsupported wallhaven URL")
+
+	// ErrInvalidWallpaperRef is returned when a wallpaper ID, link or file name has no valid ID.
+	ErrInvalidWallpaperRef = errors.New("invalid wallpaper reference")
+
+	// ErrPresetNotFound is returned by Presets.Query for unknown preset names.
+	ErrPresetNotFound = errors.New("preset not found")
+
+	// ErrBlacklisted is returned by Wallpaper for wallpapers dropped by the client's blacklist.
+	ErrBlacklisted = errors.New("wallpaper is blacklisted")
+
+	// ErrSafeMode is returned for requests and wallpapers above a safe mode client's purity cap.
+	ErrSafeMode = errors.New("blocked by safe mode")
+
+	// ErrBlacklistUnchecked is reported in Page.Errors for wallpapers left out of
+	// a page because their details, needed to check the blacklist, failed.
+	ErrBlacklistUnchecked = errors.New("wallpaper could not be checked against the blacklist")
+
+	// ErrNoPage is returned by Page.Next and Page.Prev when there is no such page.
+	ErrNoPage = errors.New("no such page")
+)
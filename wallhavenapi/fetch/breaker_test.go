@@ -0,0 +1,73 @@
+package fetch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestBreakerTransitions(t *testing.T) {
+	transient := &APIError{StatusCode: 503, Err: ErrServer}
+	notFound := &APIError{StatusCode: 404, Err: ErrNotFound}
+	const cooldown = 20 * time.Millisecond
+
+	allow := func(t *testing.T, b *Breaker, want error) {
+		t.Helper()
+		if err := b.Allow(); !errors.Is(err, want) || (want == nil && err != nil) {
+			t.Fatalf("Allow() = %v, want %v", err, want)
+		}
+	}
+	open := func(t *testing.T) *Breaker {
+		t.Helper()
+		b := NewBreaker(2, cooldown)
+		allow(t, b, nil)
+		b.Record(transient)
+		allow(t, b, nil)
+		b.Record(transient)
+		allow(t, b, ErrCircuitOpen)
+		return b
+	}
+
+	t.Run("non-transient failures reset the count", func(t *testing.T) {
+		b := NewBreaker(2, cooldown)
+		b.Record(transient)
+		b.Record(notFound)
+		b.Record(transient)
+		allow(t, b, nil)
+	})
+
+	t.Run("successful trial closes", func(t *testing.T) {
+		b := open(t)
+		time.Sleep(cooldown)
+		allow(t, b, nil)            // the half-open trial
+		allow(t, b, ErrCircuitOpen) // only one trial at a time
+		b.Record(nil)
+		allow(t, b, nil)
+		allow(t, b, nil)
+	})
+
+	t.Run("failed trial reopens", func(t *testing.T) {
+		b := open(t)
+		time.Sleep(cooldown)
+		allow(t, b, nil)
+		b.Record(transient)
+		allow(t, b, ErrCircuitOpen)
+	})
+
+	for name, err := range map[string]error{
+		"cancelled":         context.Canceled,
+		"deadline exceeded": context.DeadlineExceeded,
+		"limiter aborted":   fmt.Errorf("Rate limiter wait aborted: %w", context.Canceled),
+	} {
+		t.Run(name+" trial stays half-open", func(t *testing.T) {
+			b := open(t)
+			time.Sleep(cooldown)
+			allow(t, b, nil)
+			b.Record(err)
+			allow(t, b, nil)            // a new trial is allowed...
+			allow(t, b, ErrCircuitOpen) // ...but the circuit did not close
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package fetch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Sentinel errors classifying failed API responses.
+// Use errors.Is to test an error returned by the client against them.
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrRateLimited  = errors.New("rate limited")
+	ErrServer       = errors.New("server error")
+	ErrNotJSON      = errors.New("response is not JSON")
+)
+
+// maxErrorBody is the number of bytes of a response body kept on an APIError.
+const maxErrorBody = 512
+
+// APIError describes a request that reached Wallhaven but did not produce a usable response.
+// Err holds the matching sentinel error (ErrNotFound, ErrRateLimited, ...) when the
+// failure could be classified, so errors.Is works on an *APIError.
+// RetryAfter is the delay requested by a Retry-After header, if one was sent.
+type APIError struct {
+	StatusCode int
+	Endpoint   string
+	Message    string
+	Body       string
+	NotJSON    bool
+	RetryAfter time.Duration
+	Err        error
+}
+
+func (e *APIError) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	if e.Endpoint != "" {
+		fmt.Fprintf(&sb, " from %s", e.Endpoint)
+	}
+	switch {
+	case e.Message != "":
+		fmt.Fprintf(&sb, ": %s", e.Message)
+	case e.NotJSON:
+		sb.WriteString(": " + ErrNotJSON.Error())
+	}
+	return sb.String()
+}
+
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
+
+// checkResponse returns an *APIError if resp and its body are not a successful JSON response.
+func checkResponse(resp *http.Response, body []byte) error {
+	// An error status with an empty body is reported by status alone.
+	notJSON := !isJSON(body) && (resp.StatusCode < 300 || len(bytes.TrimSpace(body)) > 0)
+	if resp.StatusCode < 300 && !notJSON {
+		return nil
+	}
+
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Endpoint:   resp.Request.URL.Path,
+		Body:       truncate(body, maxErrorBody),
+		NotJSON:    notJSON,
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+		Err:        classify(resp.StatusCode),
+	}
+
+	if !notJSON {
+		var msg struct {
+			Error string `json:"error"`
+		}
+		if json.Unmarshal(body, &msg) == nil {
+			apiErr.Message = msg.Error
+		}
+	}
+
+	if apiErr.Err == nil && notJSON {
+		apiErr.Err = ErrNotJSON
+	}
+
+	return apiErr
+}
+
+func classify(status int) error {
+	switch {
+	case status == http.StatusNotFound:
+		return ErrNotFound
+	case status == http.StatusUnauthorized:
+		return ErrUnauthorized
+	case status == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case status >= 500:
+		return ErrServer
+	}
+	return nil
+}
+
+// isJSON reports whether a response body looks like a JSON document.
+// The body is inspected rather than the Content-Type header, since error pages
+// served by proxies such as Cloudflare are HTML regardless of the endpoint.
+func isJSON(body []byte) bool {
+	trimmed := bytes.TrimSpace(body)
+	return len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[')
+}
+
+func truncate(body []byte, n int) string {
+	if len(body) <= n {
+		return string(body)
+	}
+	return string(body[:n]) + "..."
+}
@@ -0,0 +1,98 @@
+package fetch
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCheckResponse(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		body        string
+		retryAfter  string
+		wantErr     error // nil means no *APIError at all
+		wantNotJSON bool
+		wantMessage string
+		wantRetry   time.Duration
+	}{
+		{name: "ok", status: 200, body: `{"data":{}}`},
+		{name: "ok array", status: 200, body: ` [1, 2]`},
+		{name: "html page", status: 200, body: `<html>maintenance</html>`, wantErr: ErrNotJSON, wantNotJSON: true},
+		{name: "empty ok body", status: 200, body: ``, wantErr: ErrNotJSON, wantNotJSON: true},
+		{name: "not found", status: 404, body: `{"error":"Nothing here"}`, wantErr: ErrNotFound, wantMessage: "Nothing here"},
+		{name: "unauthorized empty body", status: 401, body: ``, wantErr: ErrUnauthorized},
+		{name: "rate limited", status: 429, body: `{}`, retryAfter: "7", wantErr: ErrRateLimited, wantRetry: 7 * time.Second},
+		{name: "bad gateway html", status: 502, body: `<html>Bad Gateway</html>`, wantErr: ErrServer, wantNotJSON: true},
+		{name: "server error", status: 500, body: `{"error":"oops"}`, wantErr: ErrServer, wantMessage: "oops"},
+		{name: "bad request", status: 400, body: `{"error":"bad"}`, wantErr: &APIError{}, wantMessage: "bad"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{
+				StatusCode: tt.status,
+				Header:     http.Header{},
+				Request:    httptest.NewRequest(http.MethodGet, "https://wallhaven.cc/api/v1/w/abc123?apikey=secret", nil),
+			}
+			if tt.retryAfter != "" {
+				resp.Header.Set("Retry-After", tt.retryAfter)
+			}
+
+			err := checkResponse(resp, []byte(tt.body))
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("err = %v, want nil", err)
+				}
+				return
+			}
+
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("err = %v, want *APIError", err)
+			}
+			if _, generic := tt.wantErr.(*APIError); !generic && !errors.Is(err, tt.wantErr) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+			if apiErr.StatusCode != tt.status {
+				t.Errorf("StatusCode = %d, want %d", apiErr.StatusCode, tt.status)
+			}
+			if apiErr.Endpoint != "/api/v1/w/abc123" {
+				t.Errorf("Endpoint = %q, want the path without the query", apiErr.Endpoint)
+			}
+			if apiErr.NotJSON != tt.wantNotJSON {
+				t.Errorf("NotJSON = %v, want %v", apiErr.NotJSON, tt.wantNotJSON)
+			}
+			if apiErr.Message != tt.wantMessage {
+				t.Errorf("Message = %q, want %q", apiErr.Message, tt.wantMessage)
+			}
+			if apiErr.RetryAfter != tt.wantRetry {
+				t.Errorf("RetryAfter = %s, want %s", apiErr.RetryAfter, tt.wantRetry)
+			}
+		})
+	}
+}
+
+func TestIsTransient(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{&APIError{StatusCode: 429}, true},
+		{&APIError{StatusCode: 500}, true},
+		{&APIError{StatusCode: 502}, true},
+		{&APIError{StatusCode: 503}, true},
+		{&APIError{StatusCode: 504}, true},
+		{&APIError{StatusCode: 501}, false},
+		{&APIError{StatusCode: 404}, false},
+		{&APIError{StatusCode: 200, NotJSON: true}, false},
+		{errors.New("connection reset"), true},
+	}
+	for _, tt := range tests {
+		if got := isTransient(tt.err); got != tt.want {
+			t.Errorf("isTransient(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
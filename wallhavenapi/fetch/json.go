@@ -1,16 +1,125 @@
 package fetch
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	neturl "net/url"
+	"time"
 )
 
-func Json(url string) ([]byte, error) {
+// DefaultClient is the Client used by the package level Json and Json2Struct helpers.
+var DefaultClient = &Client{}
+
+// Client performs requests against the Wallhaven API.
+// A nil HTTPClient falls back to http.DefaultClient and an empty UserAgent
+// leaves Go's default User-Agent header in place. When Limiter is set every
+// request waits for a token first, using the priority carried by its context.
+// Failed requests are retried according to Retry, and a non-nil Breaker
+// short-circuits calls after repeated failures.
+// APIKey is sent as the X-API-Key header and never as part of the URL.
+type Client struct {
+	HTTPClient *http.Client
+	UserAgent  string
+	APIKey     string
+	Limiter    *Limiter
+	Retry      RetryPolicy
+	Breaker    *Breaker
+}
+
+func (c *Client) httpClient() *http.Client {
+	if c.HTTPClient != nil {
+		return c.HTTPClient
+	}
+	return http.DefaultClient
+}
+
+// Json performs a GET request against url and returns the raw response body.
+// Non-2xx and non-JSON responses are reported as an *APIError.
+func (c *Client) Json(url string) ([]byte, error) {
+	return c.JsonContext(context.Background(), url)
+}
+
+// JsonContext is like Json but the request is bound to ctx, so cancellation
+// and deadlines abort the request while it is in flight or waiting to be retried.
+func (c *Client) JsonContext(ctx context.Context, url string) ([]byte, error) {
+
+	if c.Breaker != nil {
+		if err := c.Breaker.Allow(); err != nil {
+			return nil, err
+		}
+	}
+
+	body, err := c.retry(ctx, http.MethodGet, url)
+
+	if c.Breaker != nil {
+		c.Breaker.Record(err)
+	}
+
+	return body, err
+}
+
+// retry sends the request, retrying transient failures according to c.Retry.
+func (c *Client) retry(ctx context.Context, method, url string) ([]byte, error) {
+	for attempt := 1; ; attempt++ {
+		body, err := c.do(ctx, method, url)
+		if err == nil || !c.Retry.shouldRetry(ctx, method, attempt, err) {
+			return body, err
+		}
+
+		delay := c.Retry.backoff(attempt, err)
+		if c.Retry.OnRetry != nil {
+			c.Retry.OnRetry(RetryEvent{URL: Redact(url), Attempt: attempt, Delay: delay, Err: err})
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, fmt.Errorf("Retry aborted: %w", ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+// do sends a single request and reads its response.
+func (c *Client) do(ctx context.Context, method, url string) ([]byte, error) {
 
-	resp, err := http.Get(url)
+	if c.Limiter != nil {
+		if err := c.Limiter.Wait(ctx, PriorityFromContext(ctx)); err != nil {
+			return nil, fmt.Errorf("Rate limiter wait aborted: %w", err)
+		}
+	}
+
+	// A key left in the URL is moved to the header so it never reaches proxy logs.
+	url, urlKey := extractAPIKey(url)
+	apiKey := c.APIKey
+	if apiKey == "" {
+		apiKey = urlKey
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create request: %w", err)
+	}
+
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+
+	if apiKey != "" {
+		req.Header.Set("X-API-Key", apiKey)
+	}
+
+	resp, err := c.httpClient().Do(req)
 	if err != nil {
+		var urlErr *neturl.Error
+		if errors.As(err, &urlErr) {
+			urlErr.URL = Redact(urlErr.URL)
+		}
 		return nil, fmt.Errorf("HTTP request failed: %w", err)
 	}
 
@@ -21,21 +130,22 @@ func Json(url string) ([]byte, error) {
 		return nil, fmt.Errorf("Failed to read response body: %w", err)
 	}
 
-	if resp.StatusCode == http.StatusNotFound {
-		return nil, fmt.Errorf("Error:404")
+	if err := checkResponse(resp, body); err != nil {
+		return nil, err
 	}
 
-	if resp.StatusCode == http.StatusUnauthorized {
-		return nil, fmt.Errorf("401 - Unauthorized")
-	}
-
-	// fmt.Println(string(body))
 	return body, nil
 }
 
-func Json2Struct[T any](url string, obj *T) error {
+// Json2Struct performs a GET request against url and decodes the JSON response into obj.
+func (c *Client) Json2Struct(url string, obj any) error {
+	return c.Json2StructContext(context.Background(), url, obj)
+}
+
+// Json2StructContext is like Json2Struct but the request is bound to ctx.
+func (c *Client) Json2StructContext(ctx context.Context, url string, obj any) error {
 
-	resp, err := Json(url)
+	resp, err := c.JsonContext(ctx, url)
 	if err != nil {
 		return err
 	}
@@ -47,3 +157,11 @@ func Json2Struct[T any](url string, obj *T) error {
 	return nil
 
 }
+
+func Json(url string) ([]byte, error) {
+	return DefaultClient.Json(url)
+}
+
+func Json2Struct[T any](url string, obj *T) error {
+	return DefaultClient.Json2Struct(url, obj)
+}
@@ -0,0 +1,141 @@
+package fetch
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Priority orders requests waiting on a Limiter.
+// Waiters in a higher lane are always served before waiters in a lower one.
+type Priority int
+
+const (
+	PriorityLow    Priority = -1
+	PriorityNormal Priority = 0
+	PriorityHigh   Priority = 1
+)
+
+type priorityKey struct{}
+
+// WithPriority returns a copy of ctx carrying p as the request priority.
+func WithPriority(ctx context.Context, p Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, p)
+}
+
+// PriorityFromContext returns the priority carried by ctx, or PriorityNormal if none is set.
+func PriorityFromContext(ctx context.Context) Priority {
+	if p, ok := ctx.Value(priorityKey{}).(Priority); ok {
+		return clampPriority(p)
+	}
+	return PriorityNormal
+}
+
+// HasPriority reports whether ctx carries an explicit priority.
+func HasPriority(ctx context.Context) bool {
+	_, ok := ctx.Value(priorityKey{}).(Priority)
+	return ok
+}
+
+func clampPriority(p Priority) Priority {
+	return min(max(p, PriorityLow), PriorityHigh)
+}
+
+// Limiter is a token bucket rate limiter with priority lanes.
+// It is safe for concurrent use and is meant to be shared by every request
+// made on behalf of one API key or IP address.
+type Limiter struct {
+	mu      sync.Mutex
+	rate    float64 // tokens per second
+	burst   float64
+	tokens  float64
+	last    time.Time
+	waiting [3]int
+	changed chan struct{}
+}
+
+// NewLimiter returns a Limiter that never allows more than requests calls
+// within any window of length per. A fraction of the allowance is available
+// as an initial burst and the rest is refilled evenly across the window.
+func NewLimiter(requests int, per time.Duration) *Limiter {
+	burst := max(1, requests/5)
+	return &Limiter{
+		rate:    float64(max(requests-burst, 1)) / per.Seconds(),
+		burst:   float64(burst),
+		tokens:  float64(burst),
+		last:    time.Now(),
+		changed: make(chan struct{}),
+	}
+}
+
+// Wait blocks until a token is available for a request of priority p,
+// or until ctx is done, in which case ctx's error is returned.
+func (l *Limiter) Wait(ctx context.Context, p Priority) error {
+	lane := int(clampPriority(p) - PriorityLow)
+
+	l.mu.Lock()
+	l.waiting[lane]++
+	for {
+		l.refill(time.Now())
+		if l.tokens >= 1 && !l.higherWaiting(lane) {
+			l.tokens--
+			l.waiting[lane]--
+			l.notify()
+			l.mu.Unlock()
+			return nil
+		}
+
+		changed := l.changed
+		var delay time.Duration
+		if l.tokens < 1 && l.rate > 0 {
+			delay = time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+		}
+		l.mu.Unlock()
+
+		var timer *time.Timer
+		var fired <-chan time.Time
+		if delay > 0 {
+			timer = time.NewTimer(delay)
+			fired = timer.C
+		}
+
+		select {
+		case <-ctx.Done():
+			if timer != nil {
+				timer.Stop()
+			}
+			l.mu.Lock()
+			l.waiting[lane]--
+			l.notify()
+			l.mu.Unlock()
+			return ctx.Err()
+		case <-fired:
+		case <-changed:
+			if timer != nil {
+				timer.Stop()
+			}
+		}
+		l.mu.Lock()
+	}
+}
+
+func (l *Limiter) refill(now time.Time) {
+	elapsed := now.Sub(l.last).Seconds()
+	l.last = now
+	l.tokens = min(l.burst, l.tokens+elapsed*l.rate)
+}
+
+func (l *Limiter) higherWaiting(lane int) bool {
+	for i := lane + 1; i < len(l.waiting); i++ {
+		if l.waiting[i] > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// notify wakes every waiter so it can re-check the bucket.
+func (l *Limiter) notify() {
+	close(l.changed)
+	l.changed = make(chan struct{})
+}
@@ -0,0 +1,49 @@
+package fetch
+
+import "net/url"
+
+// credentialParams are query parameters whose values must never be logged.
+var credentialParams = []string{"apikey"}
+
+// Redact returns rawURL with credential query parameters and any userinfo
+// password masked, making it safe to include in logs and error messages.
+// Strings that cannot be parsed as a URL are returned unchanged.
+func Redact(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+
+	values := u.Query()
+	changed := false
+	for _, key := range credentialParams {
+		if values.Has(key) {
+			values.Set(key, "REDACTED")
+			changed = true
+		}
+	}
+	if changed {
+		u.RawQuery = values.Encode()
+	}
+
+	return u.Redacted()
+}
+
+// extractAPIKey removes an apikey query parameter from rawURL, returning the
+// cleaned URL and the key so it can be sent as a header instead.
+func extractAPIKey(rawURL string) (string, string) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL, ""
+	}
+
+	values := u.Query()
+	key := values.Get("apikey")
+	if key == "" {
+		return rawURL, ""
+	}
+	values.Del("apikey")
+	u.RawQuery = values.Encode()
+
+	return u.String(), key
+}
@@ -0,0 +1,160 @@
+package fetch
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without making a request while a Breaker is open.
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+// RetryPolicy controls how failed requests are retried.
+// Only idempotent requests are retried, and only for rate limiting (429),
+// transient server errors (500, 502, 503, 504) and network failures.
+// The zero value disables retries.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	MaxAttempts int
+	// BaseDelay is the backoff before the first retry; it doubles on each attempt.
+	BaseDelay time.Duration
+	// MaxDelay caps the computed backoff. When the server asks for a longer
+	// wait with Retry-After, the request fails with its *APIError instead of
+	// sleeping, so a call cannot hang for however long the server says.
+	MaxDelay time.Duration
+	// OnRetry, if set, is called before sleeping ahead of each retry.
+	OnRetry func(RetryEvent)
+}
+
+// RetryEvent describes a failed attempt that is about to be retried.
+type RetryEvent struct {
+	URL     string
+	Attempt int
+	Delay   time.Duration
+	Err     error
+}
+
+func (p RetryPolicy) shouldRetry(ctx context.Context, method string, attempt int, err error) bool {
+	if attempt >= p.MaxAttempts || ctx.Err() != nil {
+		return false
+	}
+	if method != http.MethodGet && method != http.MethodHead {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && p.MaxDelay > 0 && apiErr.RetryAfter > p.MaxDelay {
+		return false
+	}
+	return isTransient(err)
+}
+
+// backoff returns the delay before retrying after the given attempt,
+// using exponential backoff with jitter unless the server sent Retry-After.
+func (p RetryPolicy) backoff(attempt int, err error) time.Duration {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+		return apiErr.RetryAfter
+	}
+
+	delay := p.BaseDelay << (attempt - 1)
+	if p.MaxDelay > 0 && (delay > p.MaxDelay || delay <= 0) {
+		delay = p.MaxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+	half := delay / 2
+	return half + rand.N(delay-half+1)
+}
+
+// isTransient reports whether err is worth retrying or counting against a Breaker.
+func isTransient(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+			http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+		return false
+	}
+	// Anything else that reached here failed before a response was read.
+	return true
+}
+
+// parseRetryAfter reads a Retry-After header given either in seconds or as an HTTP date.
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(value); err == nil {
+		return time.Duration(max(secs, 0)) * time.Second
+	}
+	if when, err := http.ParseTime(value); err == nil {
+		return max(time.Until(when), 0)
+	}
+	return 0
+}
+
+// Breaker is a circuit breaker that stops sending requests after Threshold
+// consecutive transient failures. Once Cooldown has passed a single trial
+// request is let through; its success closes the circuit again.
+// It is safe for concurrent use.
+type Breaker struct {
+	Threshold int
+	Cooldown  time.Duration
+
+	mu       sync.Mutex
+	failures int
+	openedAt time.Time
+	trial    bool
+}
+
+// NewBreaker returns a Breaker that opens after threshold consecutive failures
+// and stays open for cooldown.
+func NewBreaker(threshold int, cooldown time.Duration) *Breaker {
+	return &Breaker{Threshold: threshold, Cooldown: cooldown}
+}
+
+// Allow returns ErrCircuitOpen if a request should not be sent right now.
+func (b *Breaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.Threshold <= 0 || b.failures < b.Threshold {
+		return nil
+	}
+	if b.trial || time.Since(b.openedAt) < b.Cooldown {
+		return ErrCircuitOpen
+	}
+	b.trial = true
+	return nil
+}
+
+// Record updates the breaker with the outcome of a request allowed by Allow.
+func (b *Breaker) Record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.trial = false
+	// A cancelled request says nothing about the server, so it neither
+	// closes nor counts towards opening the circuit.
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return
+	}
+	if err == nil || !isTransient(err) {
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.failures >= b.Threshold {
+		b.openedAt = time.Now()
+	}
+}
@@ -0,0 +1,150 @@
+package fetch
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// reply is one canned response from a scripted test server.
+type reply struct {
+	status     int
+	retryAfter string
+}
+
+// scriptedServer answers with replies in order, repeating the last one.
+func scriptedServer(t *testing.T, replies ...reply) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(calls.Add(1))
+		rep := replies[min(n, len(replies))-1]
+		if rep.retryAfter != "" {
+			w.Header().Set("Retry-After", rep.retryAfter)
+		}
+		w.WriteHeader(rep.status)
+		if rep.status < 300 {
+			w.Write([]byte(`{"data":{}}`))
+		} else {
+			w.Write([]byte(`{"error":"scripted failure"}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &calls
+}
+
+func TestClientRetry(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 50 * time.Millisecond}
+
+	tests := []struct {
+		name      string
+		replies   []reply
+		wantErr   error
+		wantCalls int32
+	}{
+		{"success", []reply{{200, ""}}, nil, 1},
+		{"recovers from 503", []reply{{503, ""}, {503, ""}, {200, ""}}, nil, 3},
+		{"recovers from 500", []reply{{500, ""}, {200, ""}}, nil, 2},
+		{"recovers from 429", []reply{{429, "0"}, {200, ""}}, nil, 2},
+		{"gives up after MaxAttempts", []reply{{502, ""}}, ErrServer, 3},
+		{"404 is not retried", []reply{{404, ""}}, ErrNotFound, 1},
+		{"401 is not retried", []reply{{401, ""}}, ErrUnauthorized, 1},
+		{"400 is not retried", []reply{{400, ""}, {200, ""}}, &APIError{}, 1},
+		{"short Retry-After is honoured", []reply{{429, "0"}, {429, "0"}, {200, ""}}, nil, 3},
+		{"Retry-After above MaxDelay gives up", []reply{{429, "86400"}, {200, ""}}, ErrRateLimited, 1},
+		{"503 Retry-After above MaxDelay gives up", []reply{{503, "3600"}, {200, ""}}, ErrServer, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, calls := scriptedServer(t, tt.replies...)
+			var retries int
+			p := policy
+			p.OnRetry = func(RetryEvent) { retries++ }
+			c := &Client{Retry: p}
+
+			start := time.Now()
+			_, err := c.JsonContext(context.Background(), srv.URL+"/search")
+
+			switch want := tt.wantErr.(type) {
+			case nil:
+				if err != nil {
+					t.Fatalf("err = %v, want nil", err)
+				}
+			case *APIError:
+				if !errors.As(err, &want) {
+					t.Fatalf("err = %v, want *APIError", err)
+				}
+			default:
+				if !errors.Is(err, want) {
+					t.Fatalf("err = %v, want %v", err, want)
+				}
+			}
+			if got := calls.Load(); got != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", got, tt.wantCalls)
+			}
+			if retries != int(tt.wantCalls)-1 {
+				t.Errorf("OnRetry called %d times, want %d", retries, tt.wantCalls-1)
+			}
+			if elapsed := time.Since(start); elapsed > time.Second {
+				t.Errorf("took %s, want no long sleeps", elapsed)
+			}
+		})
+	}
+}
+
+func TestClientRetryAbortedByContext(t *testing.T) {
+	srv, calls := scriptedServer(t, reply{503, ""})
+	c := &Client{Retry: RetryPolicy{MaxAttempts: 3, BaseDelay: time.Minute, MaxDelay: time.Minute}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := c.JsonContext(ctx, srv.URL)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want context.DeadlineExceeded", err)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("calls = %d, want 1", got)
+	}
+}
+
+func TestClientBreakerOpens(t *testing.T) {
+	srv, calls := scriptedServer(t, reply{503, ""})
+	c := &Client{Breaker: NewBreaker(2, time.Minute)}
+
+	for range 2 {
+		if _, err := c.Json(srv.URL); !errors.Is(err, ErrServer) {
+			t.Fatalf("err = %v, want ErrServer", err)
+		}
+	}
+	if _, err := c.Json(srv.URL); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("err = %v, want ErrCircuitOpen", err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("calls = %d, want 2: an open circuit must not send requests", got)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := map[string]time.Duration{
+		"":        0,
+		"0":       0,
+		"7":       7 * time.Second,
+		"-3":      0,
+		"soon":    0,
+		"Wed, 21": 0,
+	}
+	for value, want := range tests {
+		if got := parseRetryAfter(value); got != want {
+			t.Errorf("parseRetryAfter(%q) = %s, want %s", value, got, want)
+		}
+	}
+
+	future := time.Now().Add(90 * time.Second).UTC().Format(http.TimeFormat)
+	if got := parseRetryAfter(future); got < 80*time.Second || got > 90*time.Second {
+		t.Errorf("parseRetryAfter(%q) = %s, want about 90s", future, got)
+	}
+}
@@ -110,6 +110,20 @@ func (u *URLBuilder) Has(key string) bool {
 	return exists
 }
 
+// BaseURL returns the URL without any query parameters, including appended paths.
+func (u *URLBuilder) BaseURL() string {
+	return u.baseURL
+}
+
+// Values returns a copy of all parameters.
+func (u *URLBuilder) Values() url.Values {
+	values := make(url.Values, len(u.values))
+	for k, v := range u.values {
+		values[k] = append([]string{}, v...)
+	}
+	return values
+}
+
 func (u *URLBuilder) AddExtras(extras string) {
 	u.extras = extras
 }
@@ -0,0 +1,122 @@
+package wallhavenapi
+
+import (
+	"cmp"
+	"slices"
+	"time"
+)
+
+// Predicate reports whether a wallpaper should be kept; see Query.Where.
+type Predicate func(Wallpaper) bool
+
+// MinViews keeps wallpapers with at least n views.
+func MinViews(n int) Predicate {
+	return func(w Wallpaper) bool { return w.Views >= n }
+}
+
+// MinFavorites keeps wallpapers favorited at least n times.
+func MinFavorites(n int) Predicate {
+	return func(w Wallpaper) bool { return w.Favorites >= n }
+}
+
+// MaxFileSize keeps wallpapers whose image is at most bytes large.
+func MaxFileSize(bytes int) Predicate {
+	return func(w Wallpaper) bool { return w.FileSize <= bytes }
+}
+
+// FileTypeIs keeps wallpapers of one of the given file types, given as MIME
+// types or extensions ("image/png", "png", "jpg", "jpeg").
+func FileTypeIs(types ...string) Predicate {
+	extensions := make([]string, len(types))
+	for i, fileType := range types {
+		extensions[i] = Wallpaper{FileType: fileType}.Extension()
+	}
+	return func(w Wallpaper) bool { return slices.Contains(extensions, w.Extension()) }
+}
+
+// UploadedAfter keeps wallpapers uploaded after t.
+func UploadedAfter(t time.Time) Predicate {
+	return func(w Wallpaper) bool { return w.CreatedAt.After(t) }
+}
+
+// MinPixels keeps wallpapers with at least n pixels, e.g. 3840*2160.
+func MinPixels(n int) Predicate {
+	return func(w Wallpaper) bool { return w.Resolution.Pixels() >= n }
+}
+
+// SortKey compares two wallpapers for Query.SortBy, in ascending order.
+type SortKey func(a, b Wallpaper) int
+
+// Sort keys for Query.SortBy. Use Desc to reverse one.
+var (
+	ByViews      SortKey = func(a, b Wallpaper) int { return cmp.Compare(a.Views, b.Views) }
+	ByFavorites  SortKey = func(a, b Wallpaper) int { return cmp.Compare(a.Favorites, b.Favorites) }
+	ByFileSize   SortKey = func(a, b Wallpaper) int { return cmp.Compare(a.FileSize, b.FileSize) }
+	ByUploadDate SortKey = func(a, b Wallpaper) int { return a.CreatedAt.Compare(b.CreatedAt) }
+	ByPixels     SortKey = func(a, b Wallpaper) int { return cmp.Compare(a.Resolution.Pixels(), b.Resolution.Pixels()) }
+)
+
+// Desc returns the key in descending order.
+func (k SortKey) Desc() SortKey {
+	return func(a, b Wallpaper) int { return k(b, a) }
+}
+
+// Where keeps only the wallpapers matching every predicate, for filters the API
+// does not offer. Filtering happens on the client after each page is fetched:
+// a page may hold fewer items than requested, with Page.Filtered counting the
+// rest, while All keeps fetching pages until Limit matches are found.
+// Each call adds to the existing predicates. Predicates are not saved by MarshalJSON.
+//
+//	client.Search("nature").Where(wapi.MinFavorites(100), wapi.FileTypeIs("png")).Limit(50).All(ctx)
+func (q *Query) Where(predicates ...Predicate) *Query {
+	q.where = append(q.where, predicates...)
+	return q
+}
+
+// SortBy orders results on the client by the keys in turn, the first key
+// deciding unless two wallpapers are equal on it. Each page is sorted on its
+// own; All with a Limit collects the limited matches first and yields them
+// in order. It replaces any keys set before and is not saved by MarshalJSON.
+//
+//	client.Search("nature").SortBy(wapi.ByFavorites.Desc(), wapi.ByUploadDate.Desc())
+func (q *Query) SortBy(keys ...SortKey) *Query {
+	q.sortKeys = keys
+	return q
+}
+
+// filterResults applies the client's result filters, the query's predicates
+// and its sort order to a page, counting the wallpapers removed by each.
+func (q *Query) filterResults(page *Page[Wallpaper]) {
+	page.Items, page.Dropped = q.client.filterWallpapers(page.Items)
+	if len(q.where) > 0 {
+		before := len(page.Items)
+		page.Items = slices.DeleteFunc(page.Items, func(w Wallpaper) bool { return !q.matches(w) })
+		page.Filtered = before - len(page.Items)
+	}
+	q.sortResults(page.Items)
+}
+
+// matches reports whether w satisfies every predicate of the query.
+func (q *Query) matches(w Wallpaper) bool {
+	for _, predicate := range q.where {
+		if !predicate(w) {
+			return false
+		}
+	}
+	return true
+}
+
+// sortResults sorts items in place by the query's sort keys.
+func (q *Query) sortResults(items []Wallpaper) {
+	if len(q.sortKeys) == 0 {
+		return
+	}
+	slices.SortStableFunc(items, func(a, b Wallpaper) int {
+		for _, key := range q.sortKeys {
+			if c := key(a, b); c != 0 {
+				return c
+			}
+		}
+		return 0
+	})
+}
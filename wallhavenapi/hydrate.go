@@ -0,0 +1,90 @@
+package wallhavenapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// HydrateAll replaces each wallpaper in items with its full details, as
+// returned by Wallpaper, including the tags and uploader that search and
+// collection listings leave out. Details are fetched concurrently by up to
+// the client's worker count (see WithWorkers), within its rate limit.
+// Details rejected by the client's safe mode or blacklist are not written to
+// items. Wallpapers that fail or are rejected keep their listing data, and the
+// failures are returned as a *BatchError keyed by wallpaper ID, wrapping
+// ErrSafeMode or ErrBlacklisted for rejections. Returns nil if every fetch succeeded.
+func (wh *WallhavenAPI) HydrateAll(ctx context.Context, items []Wallpaper) error {
+	return wh.hydrate(ctx, items, wh.fetchCheckedWallpaper)
+}
+
+// hydrate is HydrateAll with the function used to fetch each wallpaper.
+func (wh *WallhavenAPI) hydrate(ctx context.Context, items []Wallpaper, fetch func(context.Context, string) (Wallpaper, error)) error {
+	ids := make([]string, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+	}
+
+	details, errs := wh.fetchMany(ctx, ids, fetch)
+	for i, item := range items {
+		if detail, ok := details[item.ID]; ok {
+			items[i] = detail
+		}
+	}
+	if len(errs) > 0 {
+		return &BatchError{Errors: errs}
+	}
+	return nil
+}
+
+// DetailError is yielded by Query.All for a wallpaper whose details could not
+// be fetched, see WithDetails. Iteration continues after it.
+type DetailError struct {
+	ID  string
+	Err error
+}
+
+func (e *DetailError) Error() string {
+	return fmt.Sprintf("details for wallpaper %s: %v", e.ID, e.Err)
+}
+
+func (e *DetailError) Unwrap() error {
+	return e.Err
+}
+
+// WithDetails makes the query fetch full details for every result, see HydrateAll.
+// Failed lookups don't fail the page: those wallpapers keep their listing data
+// and the errors are reported in Page.Errors. Result filters such as a
+// blacklist are applied to the detailed wallpapers, so tags can be matched.
+// Queries on a client with a blacklist always fetch details.
+func (q *Query) WithDetails() *Query {
+	q.details = true
+	return q
+}
+
+// hydratePage fetches full details for the page's items when the query asks
+// for them or the client's blacklist needs them. With a blacklist, wallpapers
+// whose details failed are removed, as they cannot be checked.
+func (q *Query) hydratePage(ctx context.Context, page *Page[Wallpaper]) error {
+	blacklisted := !q.client.blacklist.IsEmpty()
+	if !q.details && !blacklisted {
+		return nil
+	}
+	// The page is filtered afterwards, so details are fetched unchecked here
+	// and rejected wallpapers are counted in Page.Dropped rather than as errors.
+	var batch *BatchError
+	if err := q.client.hydrate(ctx, page.Items, q.client.fetchWallpaper); errors.As(err, &batch) {
+		page.Errors = batch.Errors
+		if blacklisted {
+			for id, err := range page.Errors {
+				page.Errors[id] = fmt.Errorf("%w: %w", ErrBlacklistUnchecked, err)
+			}
+			page.Items = slices.DeleteFunc(page.Items, func(w Wallpaper) bool {
+				_, failed := page.Errors[w.ID]
+				return failed
+			})
+		}
+	}
+	return ctx.Err()
+}
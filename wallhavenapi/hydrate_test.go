@@ -0,0 +1,53 @@
+package wallhavenapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHydrateAllAppliesResultFilters(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch strings.TrimPrefix(r.URL.Path, "/w/") {
+		case "nsfw01":
+			w.Write([]byte(`{"data":{"id":"nsfw01","purity":"nsfw","uploader":{"username":"alice"}}}`))
+		case "cars01":
+			w.Write([]byte(`{"data":{"id":"cars01","purity":"sfw","tags":[{"name":"Cars"}]}}`))
+		default:
+			w.Write([]byte(`{"data":{"id":"okay01","purity":"sfw","uploader":{"username":"alice"}}}`))
+		}
+	}))
+	defer srv.Close()
+
+	var audited []string
+	wh := New(
+		WithBaseURL(srv.URL), WithoutRateLimit(), WithoutRetry(),
+		WithSafeMode(SFW, AuditFunc(func(e AuditEvent) { audited = append(audited, e.WallpaperID) })),
+	).WithBlacklist(Blacklist{Tags: []string{"cars"}})
+
+	items := []Wallpaper{{ID: "nsfw01"}, {ID: "cars01"}, {ID: "okay01"}}
+	err := wh.HydrateAll(context.Background(), items)
+
+	var batch *BatchError
+	if !errors.As(err, &batch) {
+		t.Fatalf("HydrateAll error = %v, want *BatchError", err)
+	}
+	if !errors.Is(batch.Errors["nsfw01"], ErrSafeMode) {
+		t.Errorf("nsfw01 error = %v, want ErrSafeMode", batch.Errors["nsfw01"])
+	}
+	if !errors.Is(batch.Errors["cars01"], ErrBlacklisted) {
+		t.Errorf("cars01 error = %v, want ErrBlacklisted", batch.Errors["cars01"])
+	}
+	if items[0].Purity != 0 || items[1].Tags != nil {
+		t.Errorf("rejected details were written to items: %+v", items[:2])
+	}
+	if items[2].Uploader.Username != "alice" {
+		t.Errorf("okay01 was not hydrated: %+v", items[2])
+	}
+	if len(audited) != 1 || audited[0] != "nsfw01" {
+		t.Errorf("audited %v, want [nsfw01]", audited)
+	}
+}
@@ -0,0 +1,142 @@
+package wallhavenapi
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"slices"
+
+	"github.com/davenicholson-xyz/go-wallhaven/wallhavenapi/fetch"
+)
+
+// Limit caps the number of wallpapers yielded by All.
+// A limit of zero or less means every result is yielded.
+// With Where, the limit counts matching wallpapers, however many pages that takes.
+func (q *Query) Limit(n int) *Query {
+	q.limit = n
+	return q
+}
+
+// All returns an iterator over every wallpaper matched by the query, starting at page 1.
+// Pages are fetched lazily as the loop consumes results, and fetching stops as soon as
+// the loop breaks, the last page is reached or the Limit is hit. A failed request is
+// yielded once as a non-nil error, after which iteration ends.
+//
+// With WithDetails, or on a client with a blacklist, a wallpaper whose details
+// could not be fetched is yielded with a *DetailError and iteration continues.
+// It comes with its listing data, or with only its ID when the blacklist held
+// it back as unchecked; only the former counts towards the Limit.
+// Unless ctx carries its own priority, pages are fetched at PriorityLow so that
+// interactive calls on the same client are not stuck behind a long crawl.
+//
+//	for wallpaper, err := range client.Search("nature").Limit(100).All(ctx) {
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Println(wallpaper.ID)
+//	}
+func (q *Query) All(ctx context.Context) iter.Seq2[Wallpaper, error] {
+	return func(yield func(Wallpaper, error) bool) {
+		// The sequence may be ranged over more than once, so ctx itself is left alone.
+		pageCtx := ctx
+		if !fetch.HasPriority(pageCtx) {
+			pageCtx = fetch.WithPriority(pageCtx, PriorityLow)
+		}
+
+		if len(q.sortKeys) > 0 && q.limit > 0 {
+			q.allSorted(pageCtx, yield)
+			return
+		}
+		q.all(pageCtx, yield)
+	}
+}
+
+// all yields the query's results page by page, up to the limit.
+func (q *Query) all(ctx context.Context, yield func(Wallpaper, error) bool) {
+	yielded := 0
+	for page := 1; ; page++ {
+		results, err := q.PageContext(ctx, page)
+		if err != nil {
+			yield(Wallpaper{}, err)
+			return
+		}
+
+		for _, wallpaper := range results.Items {
+			if !yield(wallpaper, detailError(results, wallpaper.ID)) {
+				return
+			}
+			yielded++
+			if q.limit > 0 && yielded >= q.limit {
+				return
+			}
+		}
+		for _, id := range heldBack(results) {
+			if !yield(Wallpaper{ID: id}, detailError(results, id)) {
+				return
+			}
+		}
+
+		if results.fetched == 0 || page >= results.Meta.LastPage {
+			return
+		}
+	}
+}
+
+// allSorted collects the limited results before yielding them in the query's
+// sort order, so that the order spans pages.
+func (q *Query) allSorted(ctx context.Context, yield func(Wallpaper, error) bool) {
+	var (
+		collected []Wallpaper
+		unchecked []Wallpaper
+		details   = make(map[string]error)
+		failed    error
+	)
+	q.all(ctx, func(wallpaper Wallpaper, err error) bool {
+		var detailErr *DetailError
+		switch {
+		case errors.As(err, &detailErr) && errors.Is(err, ErrBlacklistUnchecked):
+			unchecked = append(unchecked, wallpaper)
+			details[wallpaper.ID] = err
+		case errors.As(err, &detailErr):
+			collected = append(collected, wallpaper)
+			details[wallpaper.ID] = err
+		case err != nil:
+			failed = err
+			return false
+		default:
+			collected = append(collected, wallpaper)
+		}
+		return true
+	})
+	if failed != nil {
+		yield(Wallpaper{}, failed)
+		return
+	}
+	q.sortResults(collected)
+	for _, wallpaper := range append(collected, unchecked...) {
+		if !yield(wallpaper, details[wallpaper.ID]) {
+			return
+		}
+	}
+}
+
+// detailError returns the *DetailError for the wallpaper with id on page, or nil.
+func detailError(page *Page[Wallpaper], id string) error {
+	if err, ok := page.Errors[id]; ok {
+		return &DetailError{ID: id, Err: err}
+	}
+	return nil
+}
+
+// heldBack returns the IDs the blacklist removed from page because their
+// details could not be fetched, in sorted order.
+func heldBack(page *Page[Wallpaper]) []string {
+	var ids []string
+	for id, err := range page.Errors {
+		if errors.Is(err, ErrBlacklistUnchecked) {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+	return ids
+}
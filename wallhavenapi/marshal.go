@@ -0,0 +1,223 @@
+package wallhavenapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var collectionEndpointPattern = regexp.MustCompile(`^/collections/[^/]+/\d+$`)
+
+// queryJSON is the saved form of a Query. Field names follow the API parameters
+// where they exist, and flags and lists are spelled out so files are easy to edit.
+type queryJSON struct {
+	Endpoint    string            `json:"endpoint"`
+	Q           string            `json:"q,omitempty"`
+	Categories  []string          `json:"categories,omitempty"`
+	Purity      []string          `json:"purity,omitempty"`
+	Sorting     SortingType       `json:"sorting,omitempty"`
+	Order       OrderType         `json:"order,omitempty"`
+	Range       RangeType         `json:"range,omitempty"`
+	AtLeast     string            `json:"atleast,omitempty"`
+	Resolutions []string          `json:"resolutions,omitempty"`
+	Ratios      []string          `json:"ratios,omitempty"`
+	Colors      string            `json:"colors,omitempty"`
+	Seed        string            `json:"seed,omitempty"`
+	Limit       int               `json:"limit,omitempty"`
+	Details     bool              `json:"details,omitempty"`
+	Params      map[string]string `json:"params,omitempty"`
+}
+
+// queryJSONParams are the API parameters with a field of their own in queryJSON.
+var queryJSONParams = []string{
+	"q", "categories", "purity", "sorting", "order", "topRange",
+	"atleast", "resolutions", "ratios", "colors", "seed",
+}
+
+// MarshalJSON encodes the query in a stable schema suitable for saved searches:
+//
+//	{"endpoint": "/search", "q": "nature", "categories": ["general"],
+//	 "purity": ["sfw"], "sorting": "toplist", "range": "1M", "atleast": "3840x2160"}
+//
+// The API key and page number are never included. With random sorting the
+// current seed is saved, so the same ordering is resumed when decoded.
+func (q *Query) MarshalJSON() ([]byte, error) {
+	params := q.params()
+	data := queryJSON{
+		Endpoint:    q.endpoint(),
+		Q:           params.Get("q"),
+		Categories:  flagNames(params.Get("categories"), categoryNames),
+		Purity:      flagNames(params.Get("purity"), purityNames),
+		Sorting:     SortingType(params.Get("sorting")),
+		Order:       OrderType(params.Get("order")),
+		Range:       RangeType(params.Get("topRange")),
+		AtLeast:     params.Get("atleast"),
+		Resolutions: splitList(params.Get("resolutions")),
+		Ratios:      splitList(params.Get("ratios")),
+		Colors:      params.Get("colors"),
+		Seed:        params.Get("seed"),
+		Limit:       q.limit,
+		Details:     q.details,
+	}
+	for key := range params {
+		if !slices.Contains(queryJSONParams, key) {
+			if data.Params == nil {
+				data.Params = make(map[string]string)
+			}
+			data.Params[key] = params.Get(key)
+		}
+	}
+	return json.Marshal(data)
+}
+
+// UnmarshalJSON decodes a query saved with MarshalJSON. A query that already
+// belongs to a client keeps it; otherwise it is bound to a default
+// unauthenticated client, see WithClient. The endpoint defaults to "/search".
+func (q *Query) UnmarshalJSON(b []byte) error {
+	var data queryJSON
+	if err := json.Unmarshal(b, &data); err != nil {
+		return err
+	}
+
+	params := url.Values{}
+	for key, value := range data.Params {
+		params.Set(key, value)
+	}
+	set := func(key, value string) {
+		if value != "" {
+			params.Set(key, value)
+		}
+	}
+	set("q", data.Q)
+	set("sorting", string(data.Sorting))
+	set("order", string(data.Order))
+	set("topRange", string(data.Range))
+	set("atleast", data.AtLeast)
+	set("resolutions", strings.Join(data.Resolutions, ","))
+	set("ratios", strings.Join(data.Ratios, ","))
+	set("colors", data.Colors)
+	set("seed", data.Seed)
+	if len(data.Categories) > 0 {
+		categories, err := ParseCategory(strings.Join(data.Categories, ","))
+		if err != nil {
+			return fmt.Errorf("query categories: %w", err)
+		}
+		set("categories", CategoriesFlagToString(categories))
+	}
+	if len(data.Purity) > 0 {
+		purity, err := ParsePurity(strings.Join(data.Purity, ","))
+		if err != nil {
+			return fmt.Errorf("query purity: %w", err)
+		}
+		set("purity", PurityFlagToString(purity))
+	}
+
+	if err := q.load(data.Endpoint, params); err != nil {
+		return err
+	}
+	q.limit = data.Limit
+	q.details = data.Details
+	return nil
+}
+
+// MarshalText encodes the query's endpoint and parameters in URL form, e.g.
+// "/search?purity=100&q=nature", for flags and environment variables.
+// Like MarshalJSON it leaves out the API key and page number, but the limit
+// is not included.
+func (q *Query) MarshalText() ([]byte, error) {
+	text := q.endpoint()
+	if params := q.params(); len(params) > 0 {
+		text += "?" + params.Encode()
+	}
+	return []byte(text), nil
+}
+
+// UnmarshalText decodes a query in the form produced by MarshalText.
+// Client binding works as for UnmarshalJSON.
+func (q *Query) UnmarshalText(text []byte) error {
+	endpoint, rawQuery, _ := strings.Cut(strings.TrimSpace(string(text)), "?")
+	params, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return fmt.Errorf("query parameters: %w", err)
+	}
+	return q.load(endpoint, params)
+}
+
+// WithClient returns a copy of the query that runs on wh, for queries decoded
+// from JSON or text. Parameters set on the query take precedence over wh's
+// defaults, which fill in the rest.
+func (q *Query) WithClient(wh *WallhavenAPI) *Query {
+	derived := wh.newQuery(q.endpoint())
+	for key, value := range q.params() {
+		derived.URLBuilder.SetString(key, value[0])
+	}
+	derived.limit = q.limit
+	derived.where = slices.Clone(q.where)
+	derived.sortKeys = slices.Clone(q.sortKeys)
+	derived.details = q.details
+	derived.skipValidation = q.skipValidation
+	return derived
+}
+
+// params returns the query's parameters as saved: without the API key and page,
+// and with the current seed when random sorting is used. A zero Query has none.
+func (q *Query) params() url.Values {
+	if q.URLBuilder == nil {
+		return url.Values{}
+	}
+	params := q.URLBuilder.Values()
+	params.Del("apikey")
+	params.Del("page")
+	if params.Get("sorting") == string(Random) {
+		if seed := q.CurrentSeed(); seed != "" {
+			params.Set("seed", seed)
+		}
+	}
+	return params
+}
+
+// load replaces the query's endpoint and parameters, binding it to a default
+// client when it has none.
+func (q *Query) load(endpoint string, params url.Values) error {
+	if endpoint == "" {
+		endpoint = "/search"
+	}
+	if endpoint != "/search" && !collectionEndpointPattern.MatchString(endpoint) {
+		return fmt.Errorf("query endpoint %q: must be /search or /collections/{username}/{id}", endpoint)
+	}
+	if q.client == nil {
+		q.client = defaultClient()
+	}
+
+	loaded := q.client.newQuery(endpoint)
+	for _, key := range slices.Sorted(maps.Keys(params)) {
+		loaded.URLBuilder.SetString(key, params.Get(key))
+	}
+	q.URLBuilder = loaded.URLBuilder
+
+	q.seedMu.Lock()
+	defer q.seedMu.Unlock()
+	q.seed = ""
+	return nil
+}
+
+// flagNames turns a flag mask such as "110" into names such as ["sfw", "sketchy"].
+func flagNames(mask string, names []flagName) []string {
+	flag, err := strconv.ParseInt(mask, 2, 0)
+	if err != nil || flag == 0 {
+		return nil
+	}
+	return strings.Split(flagString(int(flag), names), ",")
+}
+
+func splitList(list string) []string {
+	if list == "" {
+		return nil
+	}
+	return strings.Split(list, ",")
+}
@@ -0,0 +1,100 @@
+package wallhavenapi
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestZeroQueryMarshals(t *testing.T) {
+	var q Query
+
+	data, err := json.Marshal(&q)
+	if err != nil {
+		t.Fatalf("MarshalJSON: %v", err)
+	}
+	if want := `{"endpoint":"/search"}`; string(data) != want {
+		t.Errorf("MarshalJSON = %s, want %s", data, want)
+	}
+
+	text, err := q.MarshalText()
+	if err != nil || string(text) != "/search" {
+		t.Errorf("MarshalText = %q, %v, want \"/search\"", text, err)
+	}
+	if got, want := q.WebURL(), WebBaseURL+"/search"; got != want {
+		t.Errorf("WebURL = %q, want %q", got, want)
+	}
+	if got := q.WithClient(New()).Raw(); got != DefaultBaseURL+"/search" {
+		t.Errorf("WithClient(New()).Raw() = %q", got)
+	}
+}
+
+func TestQueryJSONRoundTrip(t *testing.T) {
+	wh := NewWithAPIKey("secret")
+	tests := map[string]struct {
+		query *Query
+		want  string
+	}{
+		"search": {
+			query: wh.Search("nature").Categories(General, Anime).Purity(SFW).
+				MinimumResolution(Resolution{W: 3840, H: 2160}).Sort(Toplist).Range(OneMonth).Limit(40),
+			want: `{"endpoint":"/search","q":"nature","categories":["general","anime"],"purity":["sfw"],"sorting":"toplist","range":"1M","atleast":"3840x2160","limit":40}`,
+		},
+		"collection": {
+			query: wh.Collection("bob", 12).Purity(SFW, Sketchy),
+			want:  `{"endpoint":"/collections/bob/12","purity":["sfw","sketchy"]}`,
+		},
+		"empty flag mask": {
+			query: wh.Search("x").Categories(),
+			want:  `{"endpoint":"/search","q":"x"}`,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			data, err := json.Marshal(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("MarshalJSON =\n%s\nwant\n%s", data, tt.want)
+			}
+
+			var decoded Query
+			if err := json.Unmarshal(data, &decoded); err != nil {
+				t.Fatal(err)
+			}
+			again, err := json.Marshal(&decoded)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(again) != tt.want {
+				t.Errorf("re-encoded =\n%s\nwant\n%s", again, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadPresets(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "presets.json")
+	preset := `{"work-safe-4k-nature": {"q": "nature", "purity": ["sfw"], "atleast": "3840x2160"}}`
+	if err := os.WriteFile(path, []byte(preset), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	presets, err := LoadPresets(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q, err := presets.Query(New().WithCategories(People), "work-safe-4k-nature")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := DefaultBaseURL + "/search?atleast=3840x2160&categories=001&purity=100&q=nature"
+	if got := q.Raw(); got != want {
+		t.Errorf("preset query = %q, want %q", got, want)
+	}
+	if _, err := presets.Query(nil, "missing"); err == nil {
+		t.Error("missing preset: want error")
+	}
+}
@@ -0,0 +1,121 @@
+package wallhavenapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Meta holds the pagination details returned with search and collection results.
+type Meta struct {
+	CurrentPage int       `json:"current_page"`
+	LastPage    int       `json:"last_page"`
+	PerPage     int       `json:"per_page"`
+	Total       int       `json:"total"`
+	Query       QueryInfo `json:"query"`
+	Seed        string    `json:"seed,omitempty"`
+}
+
+// UnmarshalJSON accepts the numeric fields either as JSON numbers or as
+// strings, since collections return per_page as a string.
+func (m *Meta) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		CurrentPage lenientInt `json:"current_page"`
+		LastPage    lenientInt `json:"last_page"`
+		PerPage     lenientInt `json:"per_page"`
+		Total       lenientInt `json:"total"`
+		Query       QueryInfo  `json:"query"`
+		Seed        *string    `json:"seed"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*m = Meta{
+		CurrentPage: int(raw.CurrentPage),
+		LastPage:    int(raw.LastPage),
+		PerPage:     int(raw.PerPage),
+		Total:       int(raw.Total),
+		Query:       raw.Query,
+	}
+	if raw.Seed != nil {
+		m.Seed = *raw.Seed
+	}
+	return nil
+}
+
+// QueryInfo is the search echoed back in Meta. Wallhaven returns it as a plain
+// string for most searches, but as an object naming the tag for exact tag
+// searches (q=id:123); TagID and Tag are only set in that case.
+type QueryInfo struct {
+	Text  string
+	TagID int
+	Tag   string
+}
+
+// String returns the search text, or the tag name for exact tag searches.
+func (q QueryInfo) String() string {
+	if q.Text != "" {
+		return q.Text
+	}
+	return q.Tag
+}
+
+func (q *QueryInfo) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	switch {
+	case bytes.Equal(data, []byte("null")):
+		*q = QueryInfo{}
+		return nil
+	case len(data) > 0 && data[0] == '"':
+		var text string
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+		*q = QueryInfo{Text: text}
+		return nil
+	case len(data) > 0 && data[0] == '{':
+		var tag struct {
+			ID  lenientInt `json:"id"`
+			Tag string     `json:"tag"`
+		}
+		if err := json.Unmarshal(data, &tag); err != nil {
+			return err
+		}
+		*q = QueryInfo{TagID: int(tag.ID), Tag: tag.Tag}
+		return nil
+	}
+	return fmt.Errorf("unexpected meta.query value %s", data)
+}
+
+func (q QueryInfo) MarshalJSON() ([]byte, error) {
+	if q.TagID != 0 {
+		return json.Marshal(struct {
+			ID  int    `json:"id"`
+			Tag string `json:"tag"`
+		}{q.TagID, q.Tag})
+	}
+	if q.Text == "" {
+		return []byte("null"), nil
+	}
+	return json.Marshal(q.Text)
+}
+
+// lenientInt decodes from a JSON number, a numeric string, an empty string or null.
+type lenientInt int
+
+func (n *lenientInt) UnmarshalJSON(data []byte) error {
+	text := strings.Trim(string(bytes.TrimSpace(data)), `"`)
+	if text == "" || text == "null" {
+		*n = 0
+		return nil
+	}
+	v, err := strconv.Atoi(text)
+	if err != nil {
+		return fmt.Errorf("expected an integer, got %s", data)
+	}
+	*n = lenientInt(v)
+	return nil
+}
@@ -0,0 +1,184 @@
+package wallhavenapi
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/davenicholson-xyz/go-wallhaven/wallhavenapi/fetch"
+)
+
+// DefaultBaseURL is the Wallhaven API endpoint used when no WithBaseURL option is given.
+const DefaultBaseURL = "https://wallhaven.cc/api/v1"
+
+// DefaultUserAgent is the User-Agent header sent when no WithUserAgent option is given.
+const DefaultUserAgent = "go-wallhaven"
+
+// DefaultRateLimit is the number of requests per minute allowed by Wallhaven,
+// used by the built-in rate limiter unless WithRateLimit says otherwise.
+const DefaultRateLimit = 45
+
+// DefaultWorkers is the number of wallpapers fetched at once by HydrateAll
+// and Wallpapers unless WithWorkers says otherwise.
+const DefaultWorkers = 4
+
+// RetryPolicy controls how requests failing with rate limiting or transient
+// server errors are retried. See WithRetry.
+type RetryPolicy = fetch.RetryPolicy
+
+// RetryEvent is passed to RetryPolicy.OnRetry before each retry.
+type RetryEvent = fetch.RetryEvent
+
+// DefaultRetryPolicy retries rate limited and transient server failures twice,
+// starting with a one second backoff.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   time.Second,
+	MaxDelay:    30 * time.Second,
+}
+
+// Option configures a WallhavenAPI client at construction time.
+// Options are passed to New or NewWithAPIKey and applied in order.
+type Option func(*config)
+
+type config struct {
+	httpClient *http.Client
+	baseURL    string
+	userAgent  string
+	timeout    time.Duration
+	rateLimit  int
+	ratePer    time.Duration
+	retry      RetryPolicy
+	breaker    *fetch.Breaker
+
+	workers        int
+	skipValidation bool
+	safeMode       *safeMode
+}
+
+func newConfig(opts []Option) *config {
+	cfg := &config{
+		baseURL:   DefaultBaseURL,
+		userAgent: DefaultUserAgent,
+		rateLimit: DefaultRateLimit,
+		ratePer:   time.Minute,
+		retry:     DefaultRetryPolicy,
+		workers:   DefaultWorkers,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// client returns the http.Client to use for requests, applying any timeout.
+// A client supplied via WithHTTPClient is copied rather than modified.
+func (cfg *config) client() *http.Client {
+	client := cfg.httpClient
+	if client == nil {
+		client = &http.Client{}
+	}
+	if cfg.timeout > 0 {
+		copied := *client
+		copied.Timeout = cfg.timeout
+		client = &copied
+	}
+	return client
+}
+
+// limiter returns the rate limiter shared by every request made through the client,
+// or nil if rate limiting has been disabled.
+func (cfg *config) limiter() *fetch.Limiter {
+	if cfg.rateLimit <= 0 || cfg.ratePer <= 0 {
+		return nil
+	}
+	return fetch.NewLimiter(cfg.rateLimit, cfg.ratePer)
+}
+
+// WithHTTPClient sets the http.Client used for every request.
+// Use this to configure proxies, custom transports or connection pooling.
+func WithHTTPClient(client *http.Client) Option {
+	return func(cfg *config) {
+		cfg.httpClient = client
+	}
+}
+
+// WithBaseURL overrides the Wallhaven API endpoint (e.g., "http://localhost:8080/api/v1").
+// This is mainly useful for pointing the client at a local stand-in server in tests.
+func WithBaseURL(baseURL string) Option {
+	return func(cfg *config) {
+		cfg.baseURL = baseURL
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) Option {
+	return func(cfg *config) {
+		cfg.userAgent = userAgent
+	}
+}
+
+// WithTimeout sets the overall time limit for each request.
+// When combined with WithHTTPClient the supplied client is copied, not modified.
+func WithTimeout(timeout time.Duration) Option {
+	return func(cfg *config) {
+		cfg.timeout = timeout
+	}
+}
+
+// WithRateLimit caps the client at requests calls in any window of length per.
+// The limit is shared by the client and every Query created from it.
+// Authenticated accounts may be granted a higher limit than DefaultRateLimit.
+func WithRateLimit(requests int, per time.Duration) Option {
+	return func(cfg *config) {
+		cfg.rateLimit = requests
+		cfg.ratePer = per
+	}
+}
+
+// WithoutRateLimit disables the built-in rate limiter.
+func WithoutRateLimit() Option {
+	return func(cfg *config) {
+		cfg.rateLimit = 0
+	}
+}
+
+// WithRetry replaces DefaultRetryPolicy for failed requests.
+// Set OnRetry on the policy to observe each retry as it happens.
+func WithRetry(policy RetryPolicy) Option {
+	return func(cfg *config) {
+		cfg.retry = policy
+	}
+}
+
+// WithoutRetry makes every failed request return its error immediately.
+func WithoutRetry() Option {
+	return func(cfg *config) {
+		cfg.retry = RetryPolicy{}
+	}
+}
+
+// WithCircuitBreaker stops sending requests for cooldown after threshold
+// consecutive calls fail with rate limiting, server or network errors.
+// Calls made while the circuit is open fail fast with ErrCircuitOpen.
+func WithCircuitBreaker(threshold int, cooldown time.Duration) Option {
+	return func(cfg *config) {
+		cfg.breaker = fetch.NewBreaker(threshold, cooldown)
+	}
+}
+
+// WithWorkers sets how many wallpapers HydrateAll and Wallpapers fetch at once.
+// Requests are still bound by the client's rate limit. Values below 1 mean 1.
+// Use the WallhavenAPI.WithWorkers method to change it for a single call.
+func WithWorkers(n int) Option {
+	return func(cfg *config) {
+		cfg.workers = max(n, 1)
+	}
+}
+
+// WithoutValidation stops queries from being validated before they are sent.
+// Query.Validate can still be called explicitly.
+func WithoutValidation() Option {
+	return func(cfg *config) {
+		cfg.skipValidation = true
+	}
+}
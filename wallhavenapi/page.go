@@ -0,0 +1,76 @@
+package wallhavenapi
+
+import (
+	"context"
+	"fmt"
+)
+
+// Page is one page of results together with its pagination metadata.
+// Pages returned by Query.Get and Query.Page remember the query that produced
+// them, so Next and Prev can fetch neighbouring pages without rebuilding it.
+type Page[T any] struct {
+	Items []T  `json:"data"`
+	Meta  Meta `json:"meta"`
+
+	// Dropped is the number of results removed from the page by the client's
+	// blacklist or safe mode. Items holds the rest.
+	Dropped int `json:"-"`
+
+	// Filtered is the number of results removed by the query's Where predicates.
+	Filtered int `json:"-"`
+
+	// Errors holds the wallpapers whose details could not be fetched for a
+	// query using WithDetails or a client with a blacklist, keyed by wallpaper ID.
+	Errors map[string]error `json:"-"`
+
+	// fetched is the number of results Wallhaven returned, before filtering.
+	fetched int
+
+	query *Query
+	load  func(ctx context.Context, page int) (*Page[T], error)
+}
+
+// Query returns the query that produced the page, or nil for a page decoded directly.
+func (p *Page[T]) Query() *Query {
+	return p.query
+}
+
+// TotalPages returns the number of pages available for the query.
+func (p *Page[T]) TotalPages() int {
+	return p.Meta.LastPage
+}
+
+// HasNext reports whether there is a page after this one.
+func (p *Page[T]) HasNext() bool {
+	return p.Meta.CurrentPage < p.Meta.LastPage
+}
+
+// HasPrev reports whether there is a page before this one.
+func (p *Page[T]) HasPrev() bool {
+	return p.Meta.CurrentPage > 1
+}
+
+// Next fetches the page after this one.
+// Returns ErrNoPage if this is the last page.
+func (p *Page[T]) Next(ctx context.Context) (*Page[T], error) {
+	if !p.HasNext() {
+		return nil, fmt.Errorf("%w: page %d is the last page", ErrNoPage, p.Meta.CurrentPage)
+	}
+	return p.fetch(ctx, p.Meta.CurrentPage+1)
+}
+
+// Prev fetches the page before this one.
+// Returns ErrNoPage if this is the first page.
+func (p *Page[T]) Prev(ctx context.Context) (*Page[T], error) {
+	if !p.HasPrev() {
+		return nil, fmt.Errorf("%w: page %d is the first page", ErrNoPage, p.Meta.CurrentPage)
+	}
+	return p.fetch(ctx, p.Meta.CurrentPage-1)
+}
+
+func (p *Page[T]) fetch(ctx context.Context, page int) (*Page[T], error) {
+	if p.load == nil {
+		return nil, fmt.Errorf("%w: page was not produced by a query", ErrNoPage)
+	}
+	return p.load(ctx, page)
+}
@@ -6,18 +6,22 @@ import (
 	"strings"
 )
 
-// APIKey sets the API key for authenticated requests to the Wallhaven API.
+// WithAPIKey returns a copy of the client that authenticates with apikey.
 // The API key is required for accessing NSFW content and user-specific data.
-func (wh *WallhavenAPI) APIKey(apikey string) {
-	wh.urlbuilder.SetString("apikey", apikey)
+// The key is sent in the X-API-Key header, so it never appears in request URLs.
+func (wh *WallhavenAPI) WithAPIKey(apikey string) *WallhavenAPI {
+	derived := wh.derive(nil)
+	fetcher := *wh.fetcher
+	fetcher.APIKey = apikey
+	derived.fetcher = &fetcher
+	return derived
 }
 
-// Categories sets the wallpaper categories to search within.
+// WithCategories returns a copy of the client whose queries default to the given categories.
 // Accepts one or more CategoriesFlag values (e.g., General, Anime, People).
 // Multiple flags can be combined to search across multiple categories.
-func (wh *WallhavenAPI) Categories(flags ...CategoriesFlag) {
-	categoriesString := CategoriesFlagToString(flags...)
-	wh.urlbuilder.SetString("categories", categoriesString)
+func (wh *WallhavenAPI) WithCategories(flags ...CategoriesFlag) *WallhavenAPI {
+	return wh.derive(func(q *Query) { q.Categories(flags...) })
 }
 
 // Categories sets the wallpaper categories to search within inline.
@@ -29,12 +33,11 @@ func (q *Query) Categories(flags ...CategoriesFlag) *Query {
 	return q
 }
 
-// Purity sets the content purity levels to include in search results.
+// WithPurity returns a copy of the client whose queries default to the given purity levels.
 // Accepts one or more PurityFlag values (e.g., SFW, Sketchy, NSFW).
 // Multiple flags can be combined to include multiple purity levels.
-func (wh *WallhavenAPI) Purity(flags ...PurityFlag) {
-	purityString := PurityFlagToString(flags...)
-	wh.urlbuilder.SetString("purity", purityString)
+func (wh *WallhavenAPI) WithPurity(flags ...PurityFlag) *WallhavenAPI {
+	return wh.derive(func(q *Query) { q.Purity(flags...) })
 }
 
 // Purity sets the content purity levels to include in search results inline.
@@ -46,18 +49,25 @@ func (q *Query) Purity(flags ...PurityFlag) *Query {
 	return q
 }
 
-// PurityMask sets the purity filter using a bitmask.
+// WithPurityMask returns a copy of the client whose queries default to the purity bitmask.
+// The mask parameter represents a binary combination of purity flags.
+// This provides more granular control over purity filtering than the WithPurity method.
+func (wh *WallhavenAPI) WithPurityMask(mask PurityFlag) *WallhavenAPI {
+	return wh.derive(func(q *Query) { q.PurityMask(mask) })
+}
+
+// PurityMask sets the purity filter using a bitmask inline.
 // The mask parameter represents a binary combination of purity flags.
-// This provides more granular control over purity filtering than the Purity method.
-func (wh *WallhavenAPI) PurityMask(mask PurityFlag) {
+func (q *Query) PurityMask(mask PurityFlag) *Query {
 	result := fmt.Sprintf("%03s", strconv.FormatInt(int64(mask), 2))
-	wh.urlbuilder.SetString("purity", result)
+	q.URLBuilder.SetString("purity", result)
+	return q
 }
 
-// Sort sets the sorting method for search results.
+// WithSort returns a copy of the client whose queries default to the sorting method.
 // Accepts a SortingType value such as Date, Random, Views, Favorites, etc.
-func (wh *WallhavenAPI) Sort(sort SortingType) {
-	wh.urlbuilder.SetString("sorting", string(sort))
+func (wh *WallhavenAPI) WithSort(sort SortingType) *WallhavenAPI {
+	return wh.derive(func(q *Query) { q.Sort(sort) })
 }
 
 // Sort sets the sorting method for search results inline.
@@ -67,10 +77,10 @@ func (q *Query) Sort(sort SortingType) *Query {
 	return q
 }
 
-// Order sets the sort order for search results.
+// WithOrder returns a copy of the client whose queries default to the sort order.
 // Accepts an OrderType value (typically Ascending or Descending).
-func (wh *WallhavenAPI) Order(order OrderType) {
-	wh.urlbuilder.SetString("order", string(order))
+func (wh *WallhavenAPI) WithOrder(order OrderType) *WallhavenAPI {
+	return wh.derive(func(q *Query) { q.Order(order) })
 }
 
 // Order sets the sort order for search results inline.
@@ -80,11 +90,14 @@ func (q *Query) Order(order OrderType) *Query {
 	return q
 }
 
-// Range sets the time range for "top" sorting.
+// WithRange returns a copy of the client whose queries default to the "top" time range.
 // Accepts a RangeType value such as Day, Week, Month, or Year.
-// This parameter is only relevant when using "top" as the sorting method.
-func (wh *WallhavenAPI) Range(rng RangeType) {
-	wh.urlbuilder.SetString("topRange", string(rng))
+// The default only applies to queries sorted by toplist that set no range of
+// their own, so other queries from the client are unaffected.
+func (wh *WallhavenAPI) WithRange(rng RangeType) *WallhavenAPI {
+	derived := wh.derive(nil)
+	derived.toplistRange = rng
+	return derived
 }
 
 // Range sets the time range for "top" sorting inline.
@@ -95,81 +108,89 @@ func (q *Query) Range(rng RangeType) *Query {
 	return q
 }
 
-// MinimumResolution sets the minimum resolution filter for wallpapers.
-// The res parameter should be in the format "1920x1080" or similar.
+// WithMinimumResolution returns a copy of the client whose queries default to the minimum resolution.
 // Only wallpapers with resolution equal to or greater than this will be returned.
-func (wh *WallhavenAPI) MinimumResolution(res string) {
-	wh.urlbuilder.SetString("atleast", res)
+func (wh *WallhavenAPI) WithMinimumResolution(res Resolution) *WallhavenAPI {
+	return wh.derive(func(q *Query) { q.MinimumResolution(res) })
 }
 
 // MinimumResolution sets the minimum resolution filter for wallpapers inline.
-// The res parameter should be in the format "1920x1080" or similar.
 // Only wallpapers with resolution equal to or greater than this will be returned.
-func (q *Query) MinimumResolution(res string) *Query {
-	q.URLBuilder.SetString("atleast", res)
+// The zero Resolution clears the filter.
+func (q *Query) MinimumResolution(res Resolution) *Query {
+	if res.IsZero() {
+		q.URLBuilder.SetString("atleast", "")
+		return q
+	}
+	q.URLBuilder.SetString("atleast", res.String())
 	return q
 }
 
-// Seed sets a seed value for consistent random results.
+// WithSeed returns a copy of the client whose queries default to the random seed.
 // When using random sorting, the same seed will produce the same order of results.
 // This is useful for pagination with random sorting.
-func (wh *WallhavenAPI) Seed(seed string) {
-	wh.urlbuilder.SetString("seed", seed)
+func (wh *WallhavenAPI) WithSeed(seed string) *WallhavenAPI {
+	return wh.derive(func(q *Query) { q.Seed(seed) })
 }
 
 // Seed sets a seed value for consistent random results inline.
 // When using random sorting, the same seed will produce the same order of results.
-// This is useful for pagination with random sorting.
+// Without one, the seed returned with the first page is reused automatically.
+// Seeds must be 6 letters or digits; an invalid seed fails validation.
 func (q *Query) Seed(seed string) *Query {
 	q.URLBuilder.SetString("seed", seed)
 	return q
 }
 
-// Colors filters wallpapers by dominant color.
+// WithColors returns a copy of the client whose queries default to the dominant color.
 // The hex parameter should be a color in hexadecimal format (e.g., "ff0000" for red).
 // Do not include the "#" prefix in the hex value.
-func (wh *WallhavenAPI) Colors(hex string) {
-	wh.urlbuilder.SetString("colors", hex)
+func (wh *WallhavenAPI) WithColors(hex string) *WallhavenAPI {
+	return wh.derive(func(q *Query) { q.Colors(hex) })
 }
 
 // Colors filters wallpapers by dominant color inline.
-// The hex parameter should be a color in hexadecimal format (e.g., "ff0000" for red).
-// Do not include the "#" prefix in the hex value.
+// The hex parameter should be a color in hexadecimal format (e.g., "cc0000" for red).
+// Do not include the "#" prefix in the hex value. Only colours in the Palette
+// return results; use Color to map an arbitrary colour onto the palette.
 func (q *Query) Colors(hex string) *Query {
 	q.URLBuilder.SetString("colors", hex)
 	return q
 }
 
-// Resolutions filters wallpapers by specific resolutions.
-// Accepts one or more resolution strings in the format "1920x1080".
+// WithResolutions returns a copy of the client whose queries default to the specific resolutions.
 // Multiple resolutions are combined with OR logic (wallpapers matching any resolution).
-func (wh *WallhavenAPI) Resolutions(res ...string) {
-	joined := strings.Join(res, ",")
-	wh.urlbuilder.SetString("resolutions", joined)
+func (wh *WallhavenAPI) WithResolutions(res ...Resolution) *WallhavenAPI {
+	return wh.derive(func(q *Query) { q.Resolutions(res...) })
 }
 
 // Resolutions filters wallpapers by specific resolutions inline.
-// Accepts one or more resolution strings in the format "1920x1080".
 // Multiple resolutions are combined with OR logic (wallpapers matching any resolution).
-func (q *Query) Resolutions(res ...string) *Query {
-	joined := strings.Join(res, ",")
-	q.URLBuilder.SetString("resolutions", joined)
+// Use AspectRatio.Resolutions to search a whole ratio family, e.g. Ratio16x9.Resolutions().
+func (q *Query) Resolutions(res ...Resolution) *Query {
+	values := make([]string, len(res))
+	for i, r := range res {
+		values[i] = r.String()
+	}
+	q.URLBuilder.SetString("resolutions", strings.Join(values, ","))
 	return q
 }
 
-// Ratios filters wallpapers by aspect ratios.
-// Accepts one or more ratio strings in the format "16x9" or "4x3".
+// WithRatios returns a copy of the client whose queries default to the aspect ratios.
+// Accepts AspectRatio values (e.g., Ratio16x9) and the Landscape or Portrait groups.
 // Multiple ratios are combined with OR logic (wallpapers matching any ratio).
-func (wh *WallhavenAPI) Ratios(ratios ...string) {
-	joined := strings.Join(ratios, ",")
-	wh.urlbuilder.SetString("ratios", joined)
+func (wh *WallhavenAPI) WithRatios(ratios ...RatioFilter) *WallhavenAPI {
+	return wh.derive(func(q *Query) { q.Ratios(ratios...) })
 }
 
 // Ratios filters wallpapers by aspect ratios inline.
-// Accepts one or more ratio strings in the format "16x9" or "4x3".
+// Accepts AspectRatio values (e.g., Ratio16x9) and the Landscape or Portrait groups.
 // Multiple ratios are combined with OR logic (wallpapers matching any ratio).
-func (q *Query) Ratios(ratios ...string) *Query {
-	joined := strings.Join(ratios, ",")
-	q.URLBuilder.SetString("ratios", joined)
+func (q *Query) Ratios(ratios ...RatioFilter) *Query {
+	values := make([]string, len(ratios))
+	for i, r := range ratios {
+		values[i] = r.ratioParam()
+	}
+	q.URLBuilder.SetString("ratios", strings.Join(values, ","))
 	return q
 }
@@ -0,0 +1,62 @@
+package wallhavenapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+)
+
+// Presets is a set of named queries, such as curated searches shared by a team.
+// Saved to a file it is a JSON object of queries in the MarshalJSON schema:
+//
+//	{
+//	  "work-safe-4k-nature": {
+//	    "endpoint": "/search", "q": "nature",
+//	    "purity": ["sfw"], "atleast": "3840x2160"
+//	  }
+//	}
+type Presets map[string]*Query
+
+// LoadPresets reads presets from a JSON file written by Presets.Save or by hand.
+func LoadPresets(path string) (Presets, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var presets Presets
+	if err := json.Unmarshal(data, &presets); err != nil {
+		return nil, fmt.Errorf("presets %s: %w", path, err)
+	}
+	return presets, nil
+}
+
+// Save writes the presets to a JSON file, indented for editing.
+func (p Presets) Save(path string) error {
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// Names returns the preset names in sorted order.
+func (p Presets) Names() []string {
+	return slices.Sorted(maps.Keys(p))
+}
+
+// Query returns a copy of the named preset that runs on wh, so it can be
+// refined without changing the preset. A nil wh uses a default unauthenticated
+// client. Returns an error wrapping
+// ErrPresetNotFound if there is no such preset.
+func (p Presets) Query(wh *WallhavenAPI, name string) (*Query, error) {
+	q, ok := p[name]
+	if !ok || q == nil {
+		return nil, fmt.Errorf("%w: %q", ErrPresetNotFound, name)
+	}
+	if wh == nil {
+		wh = defaultClient()
+	}
+	return q.WithClient(wh), nil
+}
@@ -0,0 +1,27 @@
+package wallhavenapi
+
+import (
+	"context"
+
+	"github.com/davenicholson-xyz/go-wallhaven/wallhavenapi/fetch"
+)
+
+// Priority decides the order in which requests waiting on the client's rate limiter are sent.
+type Priority = fetch.Priority
+
+const (
+	// PriorityLow is intended for background work such as crawling many pages.
+	PriorityLow = fetch.PriorityLow
+	// PriorityNormal is used for requests whose context carries no priority.
+	PriorityNormal = fetch.PriorityNormal
+	// PriorityHigh is intended for interactive calls that should not queue behind background work.
+	PriorityHigh = fetch.PriorityHigh
+)
+
+// ContextWithPriority returns a copy of ctx that sends requests made with it at priority p.
+//
+//	ctx := wallhavenapi.ContextWithPriority(context.Background(), wallhavenapi.PriorityLow)
+//	results, err := client.Search("nature").PageContext(ctx, 10)
+func ContextWithPriority(ctx context.Context, p Priority) context.Context {
+	return fetch.WithPriority(ctx, p)
+}
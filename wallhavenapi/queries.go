@@ -1,27 +1,79 @@
 package wallhavenapi
 
 import (
+	"context"
 	"fmt"
+	"strconv"
+	"strings"
+	"sync"
 
 	"github.com/davenicholson-xyz/go-wallhaven/wallhavenapi/fetch"
 )
 
 // Query represents a query builder for Wallhaven API search operations.
 // It wraps a URLBuilder to construct and execute search queries with pagination support.
+// Queries are executed using the HTTP configuration of the client that created them.
+// With random sorting, the seed returned with the first page is reused for later pages.
 type Query struct {
 	*fetch.URLBuilder
+	client *WallhavenAPI
+	limit  int
+
+	where    []Predicate
+	sortKeys []SortKey
+	details  bool
+
+	skipValidation bool
+
+	seedMu sync.Mutex
+	seed   string
 }
 
 // Wallpaper retrieves a specific wallpaper by its ID.
 // Returns the wallpaper data or an error if the request fails or the wallpaper is not found.
-// The id parameter should be the Wallhaven wallpaper ID (e.g., "6k3oox").
-func (wh *WallhavenAPI) Wallpaper(id string) (Wallpaper, error) {
+// The ref parameter should be the Wallhaven wallpaper ID (e.g., "6k3oox"), or any
+// link or file name accepted by ParseWallpaperRef.
+func (wh *WallhavenAPI) Wallpaper(ref string) (Wallpaper, error) {
+	return wh.WallpaperContext(context.Background(), ref)
+}
+
+// WallpaperContext is like Wallpaper but the request is bound to ctx,
+// so it is aborted when ctx is cancelled or its deadline passes.
+// Unless ctx carries its own priority the request jumps the rate limiter
+// queue ahead of search pages, as single lookups are usually interactive.
+func (wh *WallhavenAPI) WallpaperContext(ctx context.Context, ref string) (Wallpaper, error) {
+	id, err := ParseWallpaperRef(ref)
+	if err != nil {
+		return Wallpaper{}, err
+	}
+	if !fetch.HasPriority(ctx) {
+		ctx = fetch.WithPriority(ctx, PriorityHigh)
+	}
+	return wh.fetchCheckedWallpaper(ctx, id)
+}
+
+// fetchCheckedWallpaper is fetchWallpaper followed by the client's result
+// filters, failing with ErrSafeMode or ErrBlacklisted for rejected wallpapers.
+func (wh *WallhavenAPI) fetchCheckedWallpaper(ctx context.Context, id string) (Wallpaper, error) {
+	wallpaper, err := wh.fetchWallpaper(ctx, id)
+	if err != nil {
+		return Wallpaper{}, err
+	}
+	if err := wh.checkWallpaper(wallpaper); err != nil {
+		return Wallpaper{}, err
+	}
+	return wallpaper, nil
+}
+
+// fetchWallpaper requests the details of the wallpaper with the given ID,
+// without applying the client's result filters.
+func (wh *WallhavenAPI) fetchWallpaper(ctx context.Context, id string) (Wallpaper, error) {
 	urlBuilder := wh.urlbuilder.Clone()
 	urlBuilder.Append(fmt.Sprintf("/w/%s", id))
 	url := urlBuilder.Build()
 
 	var wpQuery WallpaperQueryData
-	if err := fetch.Json2Struct(url, &wpQuery); err != nil {
+	if err := wh.fetcher.Json2StructContext(ctx, url, &wpQuery); err != nil {
 		return Wallpaper{}, err
 	}
 	return wpQuery.Data, nil
@@ -32,66 +84,122 @@ func (wh *WallhavenAPI) Wallpaper(id string) (Wallpaper, error) {
 // Returns a Query object that can be further configured with filters and executed.
 // Use the returned Query's methods to add pagination or execute the search.
 func (wh *WallhavenAPI) Search(query string) *Query {
-	urlBuilder := wh.urlbuilder.Clone()
-	urlBuilder.Append("/search")
-	urlBuilder.SetString("q", query)
-	return &Query{urlBuilder}
+	q := wh.newQuery("/search")
+	q.URLBuilder.SetString("q", query)
+	return q
 }
 
 // TopList creates a new query for retrieving top-rated wallpapers.
-// Automatically sets the sorting to toplist and applies any previously set filters.
+// Sets the sorting to toplist on the query and applies the client's default filters.
 // Returns a Query object that can be executed to get the most popular wallpapers.
 // Use Range() to specify the time period (day, week, month, year) before executing.
 func (wh *WallhavenAPI) TopList() *Query {
-	wh.Sort(Toplist)
-	urlBuilder := wh.urlbuilder.Clone()
-	urlBuilder.Append("/search")
-	return &Query{urlBuilder}
+	q := wh.newQuery("/search").Sort(Toplist)
+	wh.applyToplistRange(q.URLBuilder)
+	return q
 }
 
 // Hot creates a new query for retrieving currently trending wallpapers.
-// Automatically sets the sorting to hot and applies any previously set filters.
+// Sets the sorting to hot on the query and applies the client's default filters.
 // Returns a Query object that can be executed to get wallpapers that are trending now.
 func (wh *WallhavenAPI) Hot() *Query {
-	wh.Sort(Hot)
-	urlBuilder := wh.urlbuilder.Clone()
-	urlBuilder.Append("/search")
-	return &Query{urlBuilder}
+	return wh.newQuery("/search").Sort(Hot)
 }
 
 // Page executes the query for a specific page number.
 // The page parameter should be a positive integer starting from 1.
-// Returns SearchQueryData containing wallpapers and metadata for the requested page,
+// Returns a Page containing wallpapers and metadata for the requested page,
 // or an error if the request fails or the page number is invalid.
-func (q *Query) Page(page int) (SearchQueryData, error) {
+func (q *Query) Page(page int) (*Page[Wallpaper], error) {
+	return q.PageContext(context.Background(), page)
+}
+
+// PageContext is like Page but the request is bound to ctx.
+func (q *Query) PageContext(ctx context.Context, page int) (*Page[Wallpaper], error) {
 	cloned := q.URLBuilder.Clone()
-	cloned.SetInt("page", page)
-	return runQuery(cloned)
+	// SetInt would drop a zero page, silently fetching page 1 instead of
+	// letting validation reject it.
+	cloned.SetString("page", strconv.Itoa(page))
+	return q.runQuery(ctx, cloned)
 }
 
 // Get executes the query and returns the first page of results.
 // This is equivalent to calling Page(1) but more convenient for single-page requests.
-// Returns SearchQueryData containing wallpapers and metadata for the first page,
-// or an error if the request fails.
-func (q *Query) Get() (SearchQueryData, error) {
+// Returns a Page containing wallpapers and metadata for the first page,
+// or an error if the request fails. Use the Page's Next method to continue.
+func (q *Query) Get() (*Page[Wallpaper], error) {
+	return q.GetContext(context.Background())
+}
+
+// GetContext is like Get but the request is bound to ctx.
+func (q *Query) GetContext(ctx context.Context) (*Page[Wallpaper], error) {
 	cloned := q.URLBuilder.Clone()
-	return runQuery(cloned)
+	return q.runQuery(ctx, cloned)
+}
+
+// newQuery creates a query against endpoint (e.g., "/search") that starts
+// from the client's default parameters.
+func (wh *WallhavenAPI) newQuery(endpoint string) *Query {
+	urlBuilder := wh.urlbuilder.Clone()
+	urlBuilder.Append(endpoint)
+	return &Query{URLBuilder: urlBuilder, client: wh}
+}
+
+// applyToplistRange sets the client's default toplist range on url when it is
+// sorted by toplist and has no range of its own.
+func (wh *WallhavenAPI) applyToplistRange(url *fetch.URLBuilder) {
+	if wh.toplistRange != "" && url.GetString("sorting") == string(Toplist) && !url.Has("topRange") {
+		url.SetString("topRange", string(wh.toplistRange))
+	}
+}
+
+// endpoint returns the API path the query targets, relative to the client's base URL.
+// A zero Query targets "/search".
+func (q *Query) endpoint() string {
+	if q.URLBuilder == nil {
+		return "/search"
+	}
+	baseURL := DefaultBaseURL
+	if q.client != nil {
+		baseURL = q.client.urlbuilder.BaseURL()
+	}
+	if endpoint := strings.TrimPrefix(q.URLBuilder.BaseURL(), baseURL); endpoint != "" {
+		return endpoint
+	}
+	return "/search"
 }
 
 // Raw returns the query string to be run (excluding page numbers)
+// Credentials are redacted, so the result is safe to log.
 func (q *Query) Raw() string {
 	cloned := q.URLBuilder.Clone()
-	return cloned.Build()
+	return fetch.Redact(cloned.Build())
 }
 
 // runQuery executes the HTTP request to the Wallhaven API and parses the JSON response.
 // This is an internal helper function used by Page and Get methods.
-// Returns SearchQueryData with the parsed response or an error if the request or parsing fails.
-func runQuery(url *fetch.URLBuilder) (SearchQueryData, error) {
+// Returns a Page with the parsed response or an error if the request or parsing fails.
+func (q *Query) runQuery(ctx context.Context, url *fetch.URLBuilder) (*Page[Wallpaper], error) {
+	q.applySeed(url)
+	q.client.applyToplistRange(url)
+	if err := q.client.safeMode.guardRequest(url); err != nil {
+		return nil, err
+	}
+	if !q.skipValidation && !q.client.skipValidation {
+		if err := q.validate(url); err != nil {
+			return nil, err
+		}
+	}
 	urlString := url.Build()
-	var searchQuery SearchQueryData
-	if err := fetch.Json2Struct(urlString, &searchQuery); err != nil {
-		return SearchQueryData{}, err
+	page := &Page[Wallpaper]{query: q, load: q.PageContext}
+	if err := q.client.fetcher.Json2StructContext(ctx, urlString, page); err != nil {
+		return nil, err
+	}
+	page.fetched = len(page.Items)
+	q.captureSeed(url, page.Meta.Seed)
+	if err := q.hydratePage(ctx, page); err != nil {
+		return nil, err
 	}
-	return searchQuery, nil
+	q.filterResults(page)
+	return page, nil
 }
@@ -0,0 +1,98 @@
+package wallhavenapi
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// Hosts serving wallpaper pages and image files.
+const (
+	ShortLinkBaseURL = "https://whvn.cc"
+	ImageBaseURL     = "https://w.wallhaven.cc"
+	ThumbBaseURL     = "https://th.wallhaven.cc"
+)
+
+var wallpaperIDPattern = regexp.MustCompile(`^[a-z0-9]{6}$`)
+
+// ThumbSize selects one of the thumbnail variants Wallhaven serves.
+type ThumbSize string
+
+const (
+	ThumbSmall    ThumbSize = "small"
+	ThumbLarge    ThumbSize = "lg"
+	ThumbOriginal ThumbSize = "orig"
+)
+
+// ParseWallpaperRef extracts the wallpaper ID from any of the forms a wallpaper
+// is shared in: a bare ID ("6k3oox"), a wallpaper page or short link
+// (https://wallhaven.cc/w/6k3oox, https://whvn.cc/6k3oox), a full image or
+// thumbnail URL, or a downloaded file name or path ("wallhaven-6k3oox.png").
+// Returns an error wrapping ErrInvalidWallpaperRef when no ID can be found.
+func ParseWallpaperRef(ref string) (string, error) {
+	ref = strings.TrimSpace(ref)
+
+	name := ref
+	if strings.Contains(ref, "://") || strings.Contains(ref, ".cc/") {
+		raw := ref
+		if !strings.Contains(raw, "://") {
+			raw = "https://" + raw
+		}
+		u, err := url.Parse(raw)
+		if err != nil {
+			return "", fmt.Errorf("%w %q: %v", ErrInvalidWallpaperRef, ref, err)
+		}
+		segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+
+		switch host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www."); host {
+		case "wallhaven.cc":
+			// /w/{id} on the website and /api/v1/w/{id} on the API.
+			if len(segments) < 2 || segments[len(segments)-2] != "w" {
+				return "", fmt.Errorf("%w %q: not a wallpaper page", ErrInvalidWallpaperRef, ref)
+			}
+			name = segments[len(segments)-1]
+		case "whvn.cc", "w.wallhaven.cc", "th.wallhaven.cc":
+			name = segments[len(segments)-1]
+		default:
+			return "", fmt.Errorf("%w %q: not a wallhaven link", ErrInvalidWallpaperRef, ref)
+		}
+	} else {
+		name = path.Base(strings.ReplaceAll(ref, `\`, "/"))
+	}
+
+	name = strings.TrimSuffix(name, path.Ext(name))
+	name = strings.TrimPrefix(name, "wallhaven-")
+	if !wallpaperIDPattern.MatchString(name) {
+		return "", fmt.Errorf("%w %q", ErrInvalidWallpaperRef, ref)
+	}
+	return name, nil
+}
+
+// FullImageURL returns the address of the full size image for a wallpaper,
+// the same as Wallpaper.Path, without an API call. fileType is the MIME type
+// or extension of the image ("image/png", "png", "jpg"). Returns an empty
+// string if id is not a wallpaper ID or the file type is missing.
+func FullImageURL(id, fileType string) string {
+	ext := Wallpaper{FileType: fileType}.Extension()
+	if !wallpaperIDPattern.MatchString(id) || ext == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/full/%s/wallhaven-%s.%s", ImageBaseURL, id[:2], id, ext)
+}
+
+// ThumbnailURL returns the address of a wallpaper's thumbnail at the given size
+// without an API call. Thumbnails are always JPEGs, whatever the image type.
+// Returns an empty string if id is not a wallpaper ID.
+func ThumbnailURL(id string, size ThumbSize) string {
+	if !wallpaperIDPattern.MatchString(id) {
+		return ""
+	}
+	return fmt.Sprintf("%s/%s/%s/%s.jpg", ThumbBaseURL, size, id[:2], id)
+}
+
+// ShortURL returns the whvn.cc short link to a wallpaper's page.
+func ShortURL(id string) string {
+	return ShortLinkBaseURL + "/" + id
+}
@@ -0,0 +1,104 @@
+package wallhavenapi
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseWallpaperRef(t *testing.T) {
+	for _, ref := range []string{
+		"6k3oox",
+		" 6k3oox\n",
+		"https://wallhaven.cc/w/6k3oox",
+		"https://www.wallhaven.cc/w/6k3oox?foo=bar",
+		"wallhaven.cc/w/6k3oox",
+		"https://wallhaven.cc/api/v1/w/6k3oox",
+		"https://whvn.cc/6k3oox",
+		"http://whvn.cc/6k3oox",
+		"whvn.cc/6k3oox",
+		"https://w.wallhaven.cc/full/6k/wallhaven-6k3oox.jpg",
+		"https://w.wallhaven.cc/full/6k/wallhaven-6k3oox.png",
+		"https://th.wallhaven.cc/small/6k/6k3oox.jpg",
+		"https://th.wallhaven.cc/lg/6k/6k3oox.jpg",
+		"https://th.wallhaven.cc/orig/6k/6k3oox.jpg",
+		"wallhaven-6k3oox.png",
+		"wallhaven-6k3oox.jpg",
+		"/home/me/Pictures/wallhaven-6k3oox.png",
+		`C:\Users\me\Pictures\wallhaven-6k3oox.jpg`,
+	} {
+		t.Run(ref, func(t *testing.T) {
+			id, err := ParseWallpaperRef(ref)
+			if err != nil {
+				t.Fatalf("ParseWallpaperRef(%q): %v", ref, err)
+			}
+			if id != "6k3oox" {
+				t.Errorf("ParseWallpaperRef(%q) = %q, want 6k3oox", ref, id)
+			}
+		})
+	}
+}
+
+func TestParseWallpaperRefInvalid(t *testing.T) {
+	for _, ref := range []string{
+		"",
+		"6k3o",
+		"6K3OOX",
+		"6k3oox7",
+		"https://wallhaven.cc/search?q=nature",
+		"https://wallhaven.cc/tag/37",
+		"https://example.com/w/6k3oox",
+		"https://w.wallhaven.cc/full/6k/",
+		"holiday.jpg",
+	} {
+		if id, err := ParseWallpaperRef(ref); !errors.Is(err, ErrInvalidWallpaperRef) {
+			t.Errorf("ParseWallpaperRef(%q) = %q, %v, want ErrInvalidWallpaperRef", ref, id, err)
+		}
+	}
+}
+
+func TestWallpaperURLs(t *testing.T) {
+	tests := []struct {
+		name, got, want string
+	}{
+		{"full jpeg", FullImageURL("6k3oox", "image/jpeg"), "https://w.wallhaven.cc/full/6k/wallhaven-6k3oox.jpg"},
+		{"full png", FullImageURL("6k3oox", "png"), "https://w.wallhaven.cc/full/6k/wallhaven-6k3oox.png"},
+		{"full jpg", FullImageURL("6k3oox", "jpg"), "https://w.wallhaven.cc/full/6k/wallhaven-6k3oox.jpg"},
+		{"full no type", FullImageURL("6k3oox", ""), ""},
+		{"full bad id", FullImageURL("nope", "png"), ""},
+		{"thumb small", ThumbnailURL("6k3oox", ThumbSmall), "https://th.wallhaven.cc/small/6k/6k3oox.jpg"},
+		{"thumb large", ThumbnailURL("6k3oox", ThumbLarge), "https://th.wallhaven.cc/lg/6k/6k3oox.jpg"},
+		{"thumb original", ThumbnailURL("6k3oox", ThumbOriginal), "https://th.wallhaven.cc/orig/6k/6k3oox.jpg"},
+		{"thumb bad id", ThumbnailURL("nope", ThumbSmall), ""},
+		{"short", ShortURL("6k3oox"), "https://whvn.cc/6k3oox"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %q, want %q", tt.name, tt.got, tt.want)
+		}
+		if tt.want == "" {
+			continue
+		}
+		if id, err := ParseWallpaperRef(tt.got); err != nil || id != "6k3oox" {
+			t.Errorf("%s: ParseWallpaperRef(%q) = %q, %v", tt.name, tt.got, id, err)
+		}
+	}
+}
+
+func TestWallpaperAcceptsRefs(t *testing.T) {
+	srv := newTestServer(t)
+	wh := New(WithBaseURL(srv.URL), WithoutRateLimit(), WithoutRetry())
+
+	for _, ref := range []string{"6k3oox", "https://whvn.cc/6k3oox", "wallhaven-6k3oox.png"} {
+		w, err := wh.Wallpaper(ref)
+		if err != nil {
+			t.Errorf("Wallpaper(%q): %v", ref, err)
+			continue
+		}
+		if w.ID != "6k3oox" {
+			t.Errorf("Wallpaper(%q).ID = %q, want 6k3oox", ref, w.ID)
+		}
+	}
+	if _, err := wh.Wallpaper("https://example.com/w/6k3oox"); !errors.Is(err, ErrInvalidWallpaperRef) {
+		t.Errorf("Wallpaper(foreign link) = %v, want ErrInvalidWallpaperRef", err)
+	}
+}
@@ -0,0 +1,294 @@
+package wallhavenapi
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Resolution is an image size in pixels, written as "WIDTHxHEIGHT" (e.g., "1920x1080").
+type Resolution struct {
+	W, H int
+}
+
+// ParseResolution parses a resolution such as "1920x1080".
+func ParseResolution(s string) (Resolution, error) {
+	w, h, ok := splitDimensions(s, "x")
+	if !ok {
+		return Resolution{}, fmt.Errorf("invalid resolution %q: must be WIDTHxHEIGHT", s)
+	}
+	return Resolution{W: w, H: h}, nil
+}
+
+func (r Resolution) String() string {
+	return fmt.Sprintf("%dx%d", r.W, r.H)
+}
+
+// IsZero reports whether r is the zero Resolution.
+func (r Resolution) IsZero() bool {
+	return r.W == 0 && r.H == 0
+}
+
+// Pixels returns the total number of pixels, W*H.
+func (r Resolution) Pixels() int {
+	return r.W * r.H
+}
+
+// AtLeast reports whether r is at least as wide and as tall as min,
+// matching how Wallhaven applies MinimumResolution.
+func (r Resolution) AtLeast(min Resolution) bool {
+	return r.W >= min.W && r.H >= min.H
+}
+
+// Compare orders resolutions by pixel count, then by width.
+// It returns -1, 0 or +1 like cmp.Compare.
+func (r Resolution) Compare(other Resolution) int {
+	if c := cmp.Compare(r.Pixels(), other.Pixels()); c != 0 {
+		return c
+	}
+	return cmp.Compare(r.W, other.W)
+}
+
+// AspectRatio returns the ratio of r reduced to lowest terms (1920x1080 is 16x9).
+func (r Resolution) AspectRatio() AspectRatio {
+	return AspectRatio{W: r.W, H: r.H}.reduce()
+}
+
+func (r Resolution) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+func (r *Resolution) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*r = Resolution{}
+		return nil
+	}
+	parsed, err := ParseResolution(string(text))
+	if err != nil {
+		return err
+	}
+	*r = parsed
+	return nil
+}
+
+// CommonResolutions lists the resolutions offered by Wallhaven's resolution picker.
+var CommonResolutions = []Resolution{
+	{2560, 1080}, {3440, 1440}, {3840, 1600},
+	{1280, 720}, {1600, 900}, {1920, 1080}, {2560, 1440}, {3840, 2160},
+	{1280, 800}, {1600, 1000}, {1920, 1200}, {2560, 1600}, {3840, 2400},
+	{1280, 960}, {1600, 1200}, {1920, 1440}, {2560, 1920}, {3840, 2880},
+	{1280, 1024}, {1600, 1280}, {1920, 1536}, {2560, 2048}, {3840, 3072},
+}
+
+// AspectRatio is a width to height ratio, written as "WxH" (e.g., "16x9").
+type AspectRatio struct {
+	W, H int
+}
+
+// The aspect ratios offered by Wallhaven's ratio picker.
+var (
+	Ratio16x9  = AspectRatio{16, 9}
+	Ratio16x10 = AspectRatio{16, 10}
+	Ratio21x9  = AspectRatio{21, 9}
+	Ratio32x9  = AspectRatio{32, 9}
+	Ratio48x9  = AspectRatio{48, 9}
+	Ratio9x16  = AspectRatio{9, 16}
+	Ratio10x16 = AspectRatio{10, 16}
+	Ratio9x18  = AspectRatio{9, 18}
+	Ratio1x1   = AspectRatio{1, 1}
+	Ratio3x2   = AspectRatio{3, 2}
+	Ratio4x3   = AspectRatio{4, 3}
+	Ratio5x4   = AspectRatio{5, 4}
+)
+
+// CommonRatios lists the ratios offered by Wallhaven's ratio picker.
+var CommonRatios = []AspectRatio{
+	Ratio16x9, Ratio16x10, Ratio21x9, Ratio32x9, Ratio48x9,
+	Ratio9x16, Ratio10x16, Ratio9x18, Ratio1x1, Ratio3x2, Ratio4x3, Ratio5x4,
+}
+
+// ratioTolerance is how far, relative to each other, two ratios may be apart
+// and still count as the same family (3440x1440 is sold as 21:9).
+const ratioTolerance = 0.03
+
+// ParseAspectRatio parses a ratio written as "16x9", "16:9" or as the decimal
+// form Wallhaven uses in wallpaper data (e.g., "1.78").
+// A decimal is mapped back to the common ratio it was rounded from when possible.
+func ParseAspectRatio(s string) (AspectRatio, error) {
+	if w, h, ok := splitDimensions(s, "x", ":"); ok {
+		return AspectRatio{W: w, H: h}, nil
+	}
+
+	v, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil || v <= 0 || math.IsInf(v, 0) {
+		return AspectRatio{}, fmt.Errorf("invalid aspect ratio %q: must be WxH or a decimal", s)
+	}
+	for _, r := range knownRatios() {
+		if r.Decimal() == strconv.FormatFloat(round2(v), 'f', -1, 64) {
+			return r, nil
+		}
+	}
+	return AspectRatio{W: int(math.Round(v * 100)), H: 100}.reduce(), nil
+}
+
+func (a AspectRatio) String() string {
+	return fmt.Sprintf("%dx%d", a.W, a.H)
+}
+
+// IsZero reports whether a is the zero AspectRatio.
+func (a AspectRatio) IsZero() bool {
+	return a.W == 0 || a.H == 0
+}
+
+// Float returns the ratio as W/H, or 0 for the zero AspectRatio.
+func (a AspectRatio) Float() float64 {
+	if a.IsZero() {
+		return 0
+	}
+	return float64(a.W) / float64(a.H)
+}
+
+// Decimal returns the ratio rounded to two decimals in the form Wallhaven
+// uses in wallpaper data (16x9 is "1.78", 16x10 is "1.6").
+func (a AspectRatio) Decimal() string {
+	return strconv.FormatFloat(round2(a.Float()), 'f', -1, 64)
+}
+
+// Landscape reports whether the ratio is wider than it is tall.
+func (a AspectRatio) Landscape() bool {
+	return a.W > a.H
+}
+
+// Portrait reports whether the ratio is taller than it is wide.
+func (a AspectRatio) Portrait() bool {
+	return a.H > a.W
+}
+
+// Matches reports whether res belongs to the ratio's family, allowing for the
+// small differences between marketed and exact ratios (3440x1440 is 21x9).
+func (a AspectRatio) Matches(res Resolution) bool {
+	want, got := a.Float(), res.AspectRatio().Float()
+	if want == 0 || got == 0 {
+		return false
+	}
+	return math.Abs(got-want)/want <= ratioTolerance
+}
+
+// Resolutions returns the CommonResolutions in the ratio's family,
+// e.g. every 16x9 resolution from 1280x720 to 3840x2160.
+func (a AspectRatio) Resolutions() []Resolution {
+	var family []Resolution
+	for _, res := range CommonResolutions {
+		if a.Matches(res) {
+			family = append(family, res)
+		}
+	}
+	return family
+}
+
+func (a AspectRatio) MarshalText() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+func (a *AspectRatio) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*a = AspectRatio{}
+		return nil
+	}
+	parsed, err := ParseAspectRatio(string(text))
+	if err != nil {
+		return err
+	}
+	*a = parsed
+	return nil
+}
+
+// UnmarshalJSON accepts the ratio as a JSON string or a bare number.
+func (a *AspectRatio) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	return a.UnmarshalText([]byte(strings.Trim(string(data), `"`)))
+}
+
+func (a AspectRatio) ratioParam() string {
+	return a.String()
+}
+
+func (a AspectRatio) reduce() AspectRatio {
+	d := gcd(a.W, a.H)
+	if d == 0 {
+		return a
+	}
+	return AspectRatio{W: a.W / d, H: a.H / d}
+}
+
+// RatioGroup is one of the ratio groups Wallhaven accepts in place of exact ratios.
+type RatioGroup string
+
+const (
+	Landscape RatioGroup = "landscape"
+	Portrait  RatioGroup = "portrait"
+)
+
+// Matches reports whether ratio belongs to the group.
+func (g RatioGroup) Matches(ratio AspectRatio) bool {
+	switch g {
+	case Landscape:
+		return ratio.Landscape()
+	case Portrait:
+		return ratio.Portrait()
+	}
+	return false
+}
+
+func (g RatioGroup) ratioParam() string {
+	return string(g)
+}
+
+// RatioFilter is accepted by Ratios: either an AspectRatio or a RatioGroup.
+type RatioFilter interface {
+	ratioParam() string
+}
+
+// knownRatios returns the common ratios followed by the reduced ratios of
+// every common resolution, used to recognise decimal ratios.
+func knownRatios() []AspectRatio {
+	known := append([]AspectRatio{}, CommonRatios...)
+	for _, res := range CommonResolutions {
+		known = append(known, res.AspectRatio())
+	}
+	return known
+}
+
+// splitDimensions parses "AxB" using any of the given separators,
+// requiring both sides to be positive integers.
+func splitDimensions(s string, seps ...string) (int, int, bool) {
+	s = strings.TrimSpace(strings.ToLower(s))
+	for _, sep := range seps {
+		left, right, found := strings.Cut(s, sep)
+		if !found {
+			continue
+		}
+		w, errW := strconv.Atoi(left)
+		h, errH := strconv.Atoi(right)
+		if errW != nil || errH != nil || w <= 0 || h <= 0 {
+			return 0, 0, false
+		}
+		return w, h, true
+	}
+	return 0, 0, false
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
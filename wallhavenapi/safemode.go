@@ -0,0 +1,136 @@
+package wallhavenapi
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/davenicholson-xyz/go-wallhaven/wallhavenapi/fetch"
+)
+
+// AuditEvent describes a request or wallpaper blocked by safe mode.
+type AuditEvent struct {
+	Time time.Time
+	// URL is the rejected request, with credentials redacted. Empty for dropped wallpapers.
+	URL string
+	// WallpaperID is the dropped wallpaper. Empty for rejected requests.
+	WallpaperID string
+	// Purity is the purity that was requested or found on the wallpaper.
+	Purity PurityFlag
+	// Max is the highest purity safe mode allows.
+	Max PurityFlag
+}
+
+// AuditSink receives an event for every attempt blocked by safe mode.
+// Record may be called from several goroutines at once.
+type AuditSink interface {
+	Record(event AuditEvent)
+}
+
+// AuditFunc adapts a function to an AuditSink.
+type AuditFunc func(event AuditEvent)
+
+// Record calls f(event).
+func (f AuditFunc) Record(event AuditEvent) {
+	f(event)
+}
+
+// purityLevels lists the purity levels from least to most explicit.
+var purityLevels = []PurityFlag{SFW, Sketchy, NSFW}
+
+// safeMode pins the highest purity a client may request or return.
+type safeMode struct {
+	max     PurityFlag
+	allowed PurityFlag
+	sink    AuditSink
+}
+
+// WithSafeMode pins the highest purity the client will ever request or return,
+// for environments where nothing more explicit may be shown. max is SFW,
+// Sketchy or NSFW; anything else is treated as SFW.
+//
+// Queries asking for more, through Purity, PurityMask or any other means, fail
+// with an error wrapping ErrSafeMode instead of being sent, and queries
+// without a purity are sent with the allowed levels. Wallpapers above the cap
+// are dropped from pages (counted in Page.Dropped), and Wallpaper returns
+// ErrSafeMode for them. Every blocked attempt is recorded to sink, which may be nil.
+// Safe mode carries over to every client derived with the With... methods and
+// cannot be turned off.
+func WithSafeMode(max PurityFlag, sink AuditSink) Option {
+	return func(cfg *config) {
+		mode := &safeMode{max: SFW, sink: sink}
+		for _, level := range purityLevels {
+			mode.allowed |= level
+			if level == max {
+				mode.max = max
+				break
+			}
+		}
+		if mode.max != max {
+			mode.allowed = SFW
+		}
+		cfg.safeMode = mode
+	}
+}
+
+// SafeMode reports whether the client runs in safe mode, and the highest purity it allows.
+func (wh *WallhavenAPI) SafeMode() (max PurityFlag, enabled bool) {
+	if wh.safeMode == nil {
+		return 0, false
+	}
+	return wh.safeMode.max, true
+}
+
+// guardRequest rejects a request for purity levels above the cap. A request
+// without a purity is given the allowed levels, so that an account's own
+// purity settings cannot widen it.
+func (s *safeMode) guardRequest(u *fetch.URLBuilder) error {
+	if s == nil {
+		return nil
+	}
+	if !u.Has("purity") {
+		u.SetString("purity", PurityFlagToString(s.allowed))
+	}
+
+	// Check the URL as it will be sent, which includes any extras.
+	rawURL := u.Build()
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrSafeMode, err)
+	}
+	for _, value := range parsed.Query()["purity"] {
+		mask, err := strconv.ParseInt(value, 2, 0)
+		if err != nil || len(value) != 3 {
+			// Leave malformed masks to validation and the API, but never let them through.
+			mask = int64(SFW | Sketchy | NSFW)
+		}
+		if purity := PurityFlag(mask); purity&^s.allowed != 0 {
+			s.record(AuditEvent{URL: fetch.Redact(rawURL), Purity: purity})
+			return fmt.Errorf("%w: purity %s exceeds %s", ErrSafeMode, value, s.max)
+		}
+	}
+	return nil
+}
+
+// checkWallpaper returns an error wrapping ErrSafeMode if w is above the cap.
+// Wallpapers without a purity are treated as above it.
+func (s *safeMode) checkWallpaper(w Wallpaper) error {
+	if s == nil {
+		return nil
+	}
+	if w.Purity == 0 || w.Purity&^s.allowed != 0 {
+		s.record(AuditEvent{WallpaperID: w.ID, Purity: w.Purity})
+		return fmt.Errorf("%w: wallpaper %s is %s", ErrSafeMode, w.ID, w.Purity)
+	}
+	return nil
+}
+
+func (s *safeMode) record(event AuditEvent) {
+	if s.sink == nil {
+		return
+	}
+	event.Time = time.Now()
+	event.Max = s.max
+	s.sink.Record(event)
+}
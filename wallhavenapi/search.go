@@ -0,0 +1,218 @@
+package wallhavenapi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SearchExpr is a structured form of Wallhaven's q search parameter.
+// Build one with NewSearchExpr and its chainable methods, or recover one
+// from an existing q string with ParseSearchExpr.
+//
+//	expr := wallhavenapi.NewSearchExpr().Include("nature").Exclude("city lights").FileType("png")
+//	results, err := client.SearchWith(expr).Get() // q=+nature -"city lights" type:png
+type SearchExpr struct {
+	Keywords []string // fuzzy search terms
+	Required []string // tags that must be present (+tag)
+	Excluded []string // tags that must not be present (-tag)
+	Uploader string   // wallpapers uploaded by a user (@username)
+	Tag      int      // exact tag search by ID (id:123), cannot be combined with other terms
+	Type     string   // file type, "png" or "jpg" (type:png)
+	Similar  string   // wallpapers similar to a wallpaper ID (like:6k3oox)
+}
+
+// NewSearchExpr returns an empty search expression.
+func NewSearchExpr() *SearchExpr {
+	return &SearchExpr{}
+}
+
+// Keyword adds fuzzy search terms.
+func (e *SearchExpr) Keyword(terms ...string) *SearchExpr {
+	e.Keywords = append(e.Keywords, terms...)
+	return e
+}
+
+// Include requires results to be tagged with every given tag.
+func (e *SearchExpr) Include(tags ...string) *SearchExpr {
+	e.Required = append(e.Required, tags...)
+	return e
+}
+
+// Exclude drops results tagged with any of the given tags.
+func (e *SearchExpr) Exclude(tags ...string) *SearchExpr {
+	e.Excluded = append(e.Excluded, tags...)
+	return e
+}
+
+// ByUser limits results to wallpapers uploaded by username.
+func (e *SearchExpr) ByUser(username string) *SearchExpr {
+	e.Uploader = strings.TrimPrefix(username, "@")
+	return e
+}
+
+// TagID searches for an exact tag by its numeric ID.
+// Wallhaven ignores every other term when an exact tag is given.
+func (e *SearchExpr) TagID(id int) *SearchExpr {
+	e.Tag = id
+	return e
+}
+
+// FileType limits results to "png" or "jpg" files. "jpeg" is accepted as "jpg".
+func (e *SearchExpr) FileType(fileType string) *SearchExpr {
+	e.Type = normalizeFileType(fileType)
+	return e
+}
+
+// Like finds wallpapers similar to the wallpaper with the given ID.
+func (e *SearchExpr) Like(wallpaperID string) *SearchExpr {
+	e.Similar = wallpaperID
+	return e
+}
+
+// String renders the expression in Wallhaven's q syntax.
+// Terms containing spaces are wrapped in double quotes.
+func (e *SearchExpr) String() string {
+	var parts []string
+	for _, term := range e.Keywords {
+		parts = append(parts, quoteTerm(term))
+	}
+	for _, tag := range e.Required {
+		parts = append(parts, "+"+quoteTerm(tag))
+	}
+	for _, tag := range e.Excluded {
+		parts = append(parts, "-"+quoteTerm(tag))
+	}
+	if e.Uploader != "" {
+		parts = append(parts, "@"+e.Uploader)
+	}
+	if e.Tag != 0 {
+		parts = append(parts, "id:"+strconv.Itoa(e.Tag))
+	}
+	if e.Type != "" {
+		parts = append(parts, "type:"+e.Type)
+	}
+	if e.Similar != "" {
+		parts = append(parts, "like:"+e.Similar)
+	}
+	return strings.Join(parts, " ")
+}
+
+// ParseSearchExpr parses a q string such as `+nature -"city lights" @someone type:png`
+// into a SearchExpr. Double quotes group words into a single term.
+func ParseSearchExpr(q string) (*SearchExpr, error) {
+	tokens, err := tokenizeSearch(q)
+	if err != nil {
+		return nil, err
+	}
+
+	expr := NewSearchExpr()
+	for _, tok := range tokens {
+		switch {
+		case tok.prefix == '+':
+			expr.Include(tok.text)
+		case tok.prefix == '-':
+			expr.Exclude(tok.text)
+		case tok.prefix == '@':
+			expr.ByUser(tok.text)
+		case !tok.quoted && strings.HasPrefix(tok.text, "id:"):
+			id, err := strconv.Atoi(strings.TrimPrefix(tok.text, "id:"))
+			if err != nil || id <= 0 {
+				return nil, fmt.Errorf("invalid tag ID in %q", tok.text)
+			}
+			expr.TagID(id)
+		case !tok.quoted && strings.HasPrefix(tok.text, "type:"):
+			fileType := normalizeFileType(strings.TrimPrefix(tok.text, "type:"))
+			if fileType != "png" && fileType != "jpg" {
+				return nil, fmt.Errorf("invalid file type in %q: must be png or jpg", tok.text)
+			}
+			expr.FileType(fileType)
+		case !tok.quoted && strings.HasPrefix(tok.text, "like:"):
+			id := strings.TrimPrefix(tok.text, "like:")
+			if id == "" {
+				return nil, fmt.Errorf("missing wallpaper ID in %q", tok.text)
+			}
+			expr.Like(id)
+		default:
+			expr.Keyword(tok.text)
+		}
+	}
+	return expr, nil
+}
+
+// SearchWith creates a new search query from a structured expression.
+// It is equivalent to calling Search with expr.String().
+func (wh *WallhavenAPI) SearchWith(expr *SearchExpr) *Query {
+	return wh.Search(expr.String())
+}
+
+// Expression parses the query's q parameter into a SearchExpr.
+func (q *Query) Expression() (*SearchExpr, error) {
+	return ParseSearchExpr(q.URLBuilder.GetString("q"))
+}
+
+type searchToken struct {
+	prefix byte
+	text   string
+	quoted bool
+}
+
+// tokenizeSearch splits q on whitespace, keeping double quoted phrases together
+// and separating a leading +, - or @ operator from the term it applies to.
+func tokenizeSearch(q string) ([]searchToken, error) {
+	var tokens []searchToken
+	i := 0
+	for i < len(q) {
+		if q[i] == ' ' || q[i] == '\t' {
+			i++
+			continue
+		}
+
+		var tok searchToken
+		if q[i] == '+' || q[i] == '-' || q[i] == '@' {
+			tok.prefix = q[i]
+			i++
+		}
+
+		if i < len(q) && q[i] == '"' {
+			end := strings.IndexByte(q[i+1:], '"')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated quote in search %q", q)
+			}
+			tok.text = q[i+1 : i+1+end]
+			tok.quoted = true
+			i += end + 2
+		} else {
+			end := strings.IndexAny(q[i:], " \t")
+			if end < 0 {
+				end = len(q) - i
+			}
+			tok.text = q[i : i+end]
+			i += end
+		}
+
+		if tok.text == "" {
+			if tok.prefix != 0 {
+				return nil, fmt.Errorf("operator %q without a term in search %q", tok.prefix, q)
+			}
+			continue
+		}
+		tokens = append(tokens, tok)
+	}
+	return tokens, nil
+}
+
+func quoteTerm(term string) string {
+	if strings.ContainsAny(term, " \t") {
+		return `"` + term + `"`
+	}
+	return term
+}
+
+func normalizeFileType(fileType string) string {
+	fileType = strings.ToLower(strings.TrimPrefix(fileType, "."))
+	if fileType == "jpeg" {
+		return "jpg"
+	}
+	return fileType
+}
@@ -0,0 +1,105 @@
+package wallhavenapi
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSearchExpr(t *testing.T) {
+	tests := []struct {
+		q    string
+		want *SearchExpr
+		str  string // canonical rendering, when it differs from q
+	}{
+		{q: "", want: &SearchExpr{}},
+		{q: "nature", want: &SearchExpr{Keywords: []string{"nature"}}},
+		{q: "nature forest", want: &SearchExpr{Keywords: []string{"nature", "forest"}}},
+		{q: "+anime", want: &SearchExpr{Required: []string{"anime"}}},
+		{q: "-city", want: &SearchExpr{Excluded: []string{"city"}}},
+		{q: "@someone", want: &SearchExpr{Uploader: "someone"}},
+		{q: "id:123", want: &SearchExpr{Tag: 123}},
+		{q: "type:png", want: &SearchExpr{Type: "png"}},
+		{q: "type:jpg", want: &SearchExpr{Type: "jpg"}},
+		{q: "type:jpeg", want: &SearchExpr{Type: "jpg"}, str: "type:jpg"},
+		{q: "like:6k3oox", want: &SearchExpr{Similar: "6k3oox"}},
+		{q: `+"city lights"`, want: &SearchExpr{Required: []string{"city lights"}}},
+		{q: `-"city lights"`, want: &SearchExpr{Excluded: []string{"city lights"}}},
+		{q: `"id:123"`, want: &SearchExpr{Keywords: []string{"id:123"}}, str: "id:123"},
+		{
+			q: `nature +anime -"city lights" @someone type:png like:6k3oox`,
+			want: &SearchExpr{
+				Keywords: []string{"nature"},
+				Required: []string{"anime"},
+				Excluded: []string{"city lights"},
+				Uploader: "someone",
+				Type:     "png",
+				Similar:  "6k3oox",
+			},
+		},
+		{q: "  +anime \t -city  ", want: &SearchExpr{Required: []string{"anime"}, Excluded: []string{"city"}}, str: "+anime -city"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.q, func(t *testing.T) {
+			got, err := ParseSearchExpr(tt.q)
+			if err != nil {
+				t.Fatalf("ParseSearchExpr(%q): %v", tt.q, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseSearchExpr(%q) = %+v, want %+v", tt.q, got, tt.want)
+			}
+			str := tt.str
+			if str == "" {
+				str = tt.q
+			}
+			if got.String() != str {
+				t.Errorf("String() = %q, want %q", got.String(), str)
+			}
+		})
+	}
+}
+
+func TestParseSearchExprErrors(t *testing.T) {
+	for _, q := range []string{
+		`+"city lights`,
+		"+",
+		"nature -",
+		`@""`,
+		"id:abc",
+		"id:0",
+		"id:-4",
+		"type:gif",
+		"type:",
+		"like:",
+	} {
+		if expr, err := ParseSearchExpr(q); err == nil {
+			t.Errorf("ParseSearchExpr(%q) = %+v, want error", q, expr)
+		}
+	}
+}
+
+func TestSearchExprBuilder(t *testing.T) {
+	expr := NewSearchExpr().
+		Keyword("sunset").
+		Include("nature", "night sky").
+		Exclude("city lights").
+		ByUser("@someone").
+		FileType(".JPEG").
+		Like("6k3oox")
+
+	want := `sunset +nature +"night sky" -"city lights" @someone type:jpg like:6k3oox`
+	if got := expr.String(); got != want {
+		t.Fatalf("String() = %q, want %q", got, want)
+	}
+	parsed, err := ParseSearchExpr(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(parsed, expr) {
+		t.Errorf("ParseSearchExpr(String()) = %+v, want %+v", parsed, expr)
+	}
+
+	q := New().SearchWith(NewSearchExpr().TagID(37))
+	if got, err := q.Expression(); err != nil || got.Tag != 37 {
+		t.Errorf("Expression() = %+v, %v, want tag 37", got, err)
+	}
+}
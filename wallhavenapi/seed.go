@@ -0,0 +1,56 @@
+package wallhavenapi
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/davenicholson-xyz/go-wallhaven/wallhavenapi/fetch"
+)
+
+var seedPattern = regexp.MustCompile(`^[a-zA-Z0-9]{6}$`)
+
+// ValidateSeed checks that seed has the format Wallhaven uses for random seeds,
+// six letters or digits (e.g., "Xk8sPq").
+func ValidateSeed(seed string) error {
+	if !seedPattern.MatchString(seed) {
+		return fmt.Errorf("%w %q: must be 6 letters or digits", ErrInvalidSeed, seed)
+	}
+	return nil
+}
+
+// CurrentSeed returns the seed the query uses for random sorting: either the one set
+// with Seed, or the one Wallhaven returned with the first page fetched.
+// Persist it and pass it to Seed later to resume the same random ordering.
+// Returns an empty string if no seed is known yet.
+func (q *Query) CurrentSeed() string {
+	if seed := q.URLBuilder.GetString("seed"); seed != "" {
+		return seed
+	}
+	q.seedMu.Lock()
+	defer q.seedMu.Unlock()
+	return q.seed
+}
+
+// applySeed adds the seed captured from an earlier response to url when it
+// uses random sorting without a seed of its own.
+func (q *Query) applySeed(url *fetch.URLBuilder) {
+	if url.Has("seed") || url.GetString("sorting") != string(Random) {
+		return
+	}
+	q.seedMu.Lock()
+	defer q.seedMu.Unlock()
+	url.SetString("seed", q.seed)
+}
+
+// captureSeed remembers the seed of a random sorted response so that later
+// pages continue the same ordering instead of starting a new one.
+func (q *Query) captureSeed(url *fetch.URLBuilder, seed string) {
+	if seed == "" || url.GetString("sorting") != string(Random) {
+		return
+	}
+	q.seedMu.Lock()
+	defer q.seedMu.Unlock()
+	if q.seed == "" {
+		q.seed = seed
+	}
+}
@@ -2,81 +2,76 @@ package wallhavenapi
 
 import (
 	"fmt"
+	"image/color"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 )
 
 type WallpaperQueryData struct {
 	Data Wallpaper `json:"data"`
 }
 
-type SearchQueryData struct {
-	Wallpapers []Wallpaper `json:"data"`
-	Meta       struct {
-		CurrentPage int `json:"current_page"`
-		LastPage    int `json:"last_page"`
-		Total       int `json:"total"`
-		PerPage     int
-		Query       string `json:"query"`
-		Seed        string `json:"seed"`
-	} `json:"meta"`
+// Wallpaper is a single wallpaper as returned by search results and the wallpaper endpoint.
+// Search results leave Tags empty and may omit some Uploader details.
+// It decodes from and encodes back to Wallhaven's JSON representation.
+type Wallpaper struct {
+	ID         string
+	URL        string
+	ShortURL   string
+	Uploader   Uploader
+	Views      int
+	Favorites  int
+	Source     string
+	Purity     PurityFlag
+	Category   CategoriesFlag
+	DimensionX int
+	DimensionY int
+	Resolution Resolution
+	Ratio      AspectRatio
+	FileSize   int
+	FileType   string
+	CreatedAt  time.Time
+	Colors     []color.RGBA
+	Path       string
+	Thumbs     Thumbnails
+	Tags       []Tag
 }
 
-type Wallpaper struct {
-	ID       string `json:"id"`
-	URL      string `json:"url"`
-	ShortURL string `json:"short_url"`
-	Uploader struct {
-		Username string `json:"username"`
-		Group    string `json:"group"`
-		Avatar   struct {
-			Size200px string `json:"200px"`
-			Size128px string `json:"128px"`
-			Size32px  string `json:"32px"`
-			Size20px  string `json:"20px"`
-		} `json:"avatar"`
-	} `json:"uploader"`
-	Views      int      `json:"views"`
-	Favorites  int      `json:"favorites"`
-	Source     string   `json:"source"`
-	Purity     string   `json:"purity"`
-	Category   string   `json:"category"`
-	DimensionX int      `json:"dimension_x"`
-	DimensionY int      `json:"dimension_y"`
-	Resolution string   `json:"resolution"`
-	Ratio      string   `json:"ratio"`
-	FileSize   int      `json:"file_size"`
-	FileType   string   `json:"file_type"`
-	CreatedAt  string   `json:"created_at"`
-	Colors     []string `json:"colors"`
-	Path       string   `json:"path"`
-	Thumbs     struct {
-		Large    string `json:"large"`
-		Original string `json:"original"`
-		Small    string `json:"small"`
-	} `json:"thumbs"`
-	Tags []struct {
-		ID         int    `json:"id"`
-		Name       string `json:"name"`
-		Alias      string `json:"alias"`
-		CategoryID int    `json:"category_id"`
-		Category   string `json:"category"`
-		Purity     string `json:"purity"`
-		CreatedAt  string `json:"created_at"`
-	} `json:"tags"`
+type Uploader struct {
+	Username string `json:"username"`
+	Group    string `json:"group"`
+	Avatar   Avatar `json:"avatar"`
+}
+
+type Avatar struct {
+	Size200px string `json:"200px"`
+	Size128px string `json:"128px"`
+	Size32px  string `json:"32px"`
+	Size20px  string `json:"20px"`
+}
+
+type Thumbnails struct {
+	Large    string `json:"large"`
+	Original string `json:"original"`
+	Small    string `json:"small"`
 }
 
 type TagData struct {
 	Data Tag `json:"data"`
 }
 
+// Tag describes a Wallhaven tag. Category is the tag's own category
+// (e.g., "Anime & Manga"), not a wallpaper CategoriesFlag.
 type Tag struct {
-	ID         int    `json:"id"`
-	Name       string `json:"name"`
-	Alias      string `json:"alias"`
-	CategoryID int    `json:"category_id"`
-	Category   string `json:"category"`
-	Purity     string `json:"purity"`
-	CreatedAt  string `json:"created_at"`
+	ID         int
+	Name       string
+	Alias      string
+	CategoryID int
+	Category   string
+	Purity     PurityFlag
+	CreatedAt  time.Time
 }
 
 type UserSettingsData struct {
@@ -107,16 +102,6 @@ type Collection struct {
 	Count  int    `json:"count"`
 }
 
-type CollectionQueryData struct {
-	Data []Wallpaper `json:"data"`
-	Meta struct {
-		CurrentPage int    `json:"current_page"`
-		LastPage    int    `json:"last_page"`
-		PerPage     string `json:"per_page"`
-		Total       int    `json:"total"`
-	} `json:"meta"`
-}
-
 type SortingType string
 
 const (
@@ -181,3 +166,64 @@ func CategoriesFlagToString(flags ...CategoriesFlag) string {
 	result := fmt.Sprintf("%03s", strconv.FormatInt(int64(combined), 2))
 	return result
 }
+
+var purityNames = []flagName{{SFW, "sfw"}, {Sketchy, "sketchy"}, {NSFW, "nsfw"}}
+
+var categoryNames = []flagName{{General, "general"}, {Anime, "anime"}, {People, "people"}}
+
+type flagName struct {
+	flag int
+	name string
+}
+
+// String returns the name Wallhaven uses for the purity ("sfw", "sketchy", "nsfw").
+// Combined flags are joined with commas.
+func (p PurityFlag) String() string {
+	return flagString(int(p), purityNames)
+}
+
+// ParsePurity parses a purity name as used in Wallhaven's JSON ("sfw", "sketchy", "nsfw").
+// Comma separated names are combined; an empty string parses as 0.
+func ParsePurity(s string) (PurityFlag, error) {
+	flag, err := parseFlag(s, purityNames, "purity")
+	return PurityFlag(flag), err
+}
+
+// String returns the name Wallhaven uses for the category ("general", "anime", "people").
+// Combined flags are joined with commas.
+func (c CategoriesFlag) String() string {
+	return flagString(int(c), categoryNames)
+}
+
+// ParseCategory parses a category name as used in Wallhaven's JSON ("general", "anime", "people").
+// Comma separated names are combined; an empty string parses as 0.
+func ParseCategory(s string) (CategoriesFlag, error) {
+	flag, err := parseFlag(s, categoryNames, "category")
+	return CategoriesFlag(flag), err
+}
+
+func flagString(flag int, names []flagName) string {
+	var parts []string
+	for _, n := range names {
+		if flag&n.flag != 0 {
+			parts = append(parts, n.name)
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+func parseFlag(s string, names []flagName, kind string) (int, error) {
+	var flag int
+	for _, part := range strings.Split(s, ",") {
+		part = strings.ToLower(strings.TrimSpace(part))
+		if part == "" {
+			continue
+		}
+		i := slices.IndexFunc(names, func(n flagName) bool { return n.name == part })
+		if i < 0 {
+			return 0, fmt.Errorf("unknown %s %q", kind, part)
+		}
+		flag |= names[i].flag
+	}
+	return flag, nil
+}
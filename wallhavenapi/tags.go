@@ -1,9 +1,8 @@
 package wallhavenapi
 
 import (
+	"context"
 	"fmt"
-
-	"github.com/davenicholson-xyz/go-wallhaven/wallhavenapi/fetch"
 )
 
 // Tag retrieves detailed information about a specific tag by its ID.
@@ -11,11 +10,17 @@ import (
 // Returns Tag data including the tag name, category, purity level, and creation date,
 // or an error if the request fails or the tag is not found.
 func (wh *WallhavenAPI) Tag(id int) (Tag, error) {
-	wh.urlbuilder.Append(fmt.Sprintf("/tag/%d", id))
-	url := wh.urlbuilder.Build()
+	return wh.TagContext(context.Background(), id)
+}
+
+// TagContext is like Tag but the request is bound to ctx.
+func (wh *WallhavenAPI) TagContext(ctx context.Context, id int) (Tag, error) {
+	urlBuilder := wh.urlbuilder.Clone()
+	urlBuilder.Append(fmt.Sprintf("/tag/%d", id))
+	url := urlBuilder.Build()
 
 	var tagQuery TagData
-	if err := fetch.Json2Struct(url, &tagQuery); err != nil {
+	if err := wh.fetcher.Json2StructContext(ctx, url, &tagQuery); err != nil {
 		return Tag{}, err
 	}
 
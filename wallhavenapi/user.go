@@ -1,19 +1,22 @@
 package wallhavenapi
 
 import (
+	"context"
 	"fmt"
-
-	"github.com/davenicholson-xyz/go-wallhaven/wallhavenapi/fetch"
 )
 
 // UserSettings retrieves the current user's account settings and preferences.
-// This function requires an API key to be set using ApiKey() before calling.
+// This function requires a client created with NewWithAPIKey() or WithAPIKey().
 // Returns UserSettings containing account preferences, avatar, and profile information,
 // or an error if no API key is provided or the request fails.
 func (wh *WallhavenAPI) UserSettings() (UserSettings, error) {
-	key := wh.urlbuilder.Has("apikey")
-	if !key {
-		return UserSettings{}, fmt.Errorf("API key required to fetch user settings")
+	return wh.UserSettingsContext(context.Background())
+}
+
+// UserSettingsContext is like UserSettings but the request is bound to ctx.
+func (wh *WallhavenAPI) UserSettingsContext(ctx context.Context) (UserSettings, error) {
+	if !wh.hasAPIKey() {
+		return UserSettings{}, fmt.Errorf("%w to fetch user settings", ErrAPIKeyRequired)
 	}
 
 	urlBuilder := wh.urlbuilder.Clone()
@@ -21,7 +24,7 @@ func (wh *WallhavenAPI) UserSettings() (UserSettings, error) {
 	url := urlBuilder.Build()
 
 	var userQuery UserSettingsData
-	if err := fetch.Json2Struct(url, &userQuery); err != nil {
+	if err := wh.fetcher.Json2StructContext(ctx, url, &userQuery); err != nil {
 		return UserSettings{}, err
 	}
 
@@ -29,14 +32,18 @@ func (wh *WallhavenAPI) UserSettings() (UserSettings, error) {
 }
 
 // MyCollections retrieves all collections belonging to the authenticated user.
-// This function requires an API key to be set using ApiKey() before calling.
+// This function requires a client created with NewWithAPIKey() or WithAPIKey().
 // Returns a slice of Collection objects containing the user's personal collections,
 // or an error if no API key is provided or the request fails.
 // Only collections owned by the authenticated user are returned.
 func (wh *WallhavenAPI) MyCollections() ([]Collection, error) {
-	key := wh.urlbuilder.Has("apikey")
-	if !key {
-		return []Collection{}, fmt.Errorf("API key required to fetch your collections")
+	return wh.MyCollectionsContext(context.Background())
+}
+
+// MyCollectionsContext is like MyCollections but the request is bound to ctx.
+func (wh *WallhavenAPI) MyCollectionsContext(ctx context.Context) ([]Collection, error) {
+	if !wh.hasAPIKey() {
+		return []Collection{}, fmt.Errorf("%w to fetch your collections", ErrAPIKeyRequired)
 	}
 
 	urlBuilder := wh.urlbuilder.Clone()
@@ -44,7 +51,7 @@ func (wh *WallhavenAPI) MyCollections() ([]Collection, error) {
 	url := urlBuilder.Build()
 
 	var collectionsQuery CollectionData
-	if err := fetch.Json2Struct(url, &collectionsQuery); err != nil {
+	if err := wh.fetcher.Json2StructContext(ctx, url, &collectionsQuery); err != nil {
 		return []Collection{}, err
 	}
 
@@ -57,12 +64,17 @@ func (wh *WallhavenAPI) MyCollections() ([]Collection, error) {
 // or an error if the user is not found or the request fails.
 // Private collections are not included in the results unless you have appropriate access.
 func (wh *WallhavenAPI) Collections(username string) ([]Collection, error) {
+	return wh.CollectionsContext(context.Background(), username)
+}
+
+// CollectionsContext is like Collections but the request is bound to ctx.
+func (wh *WallhavenAPI) CollectionsContext(ctx context.Context, username string) ([]Collection, error) {
 	urlBuilder := wh.urlbuilder.Clone()
 	urlBuilder.Append(fmt.Sprintf("/collections/%s", username))
 	url := urlBuilder.Build()
 
 	var collectionsQuery CollectionData
-	if err := fetch.Json2Struct(url, &collectionsQuery); err != nil {
+	if err := wh.fetcher.Json2StructContext(ctx, url, &collectionsQuery); err != nil {
 		return []Collection{}, err
 	}
 
@@ -72,7 +84,5 @@ func (wh *WallhavenAPI) Collections(username string) ([]Collection, error) {
 // Collection creates a new query for returning wallpapers from a collection.
 // Use the returned Query's methods to fetch the first page via Get() or Page(x)
 func (wh *WallhavenAPI) Collection(username string, id int) *Query {
-	urlBuilder := wh.urlbuilder.Clone()
-	urlBuilder.Append(fmt.Sprintf("/collections/%s/%d", username, id))
-	return &Query{urlBuilder}
+	return wh.newQuery(fmt.Sprintf("/collections/%s/%d", username, id))
 }
@@ -0,0 +1,183 @@
+package wallhavenapi
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/davenicholson-xyz/go-wallhaven/wallhavenapi/fetch"
+)
+
+// ParamError describes one invalid query parameter.
+type ParamError struct {
+	Param      string // URL parameter name, e.g. "atleast"
+	Value      string // offending value
+	Problem    string // what is wrong with it
+	Suggestion string // how to fix it, may be empty
+	Err        error  // sentinel the error wraps, ErrInvalidQuery unless more specific
+}
+
+func (e *ParamError) Error() string {
+	msg := fmt.Sprintf("%s %q: %s", e.Param, e.Value, e.Problem)
+	if e.Suggestion != "" {
+		msg += " (" + e.Suggestion + ")"
+	}
+	return msg
+}
+
+func (e *ParamError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationError lists every invalid parameter found by Query.Validate.
+// It unwraps to each *ParamError, so errors.As and errors.Is see all of them.
+type ValidationError struct {
+	Errors []*ParamError
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+	return "invalid query: " + strings.Join(msgs, "; ")
+}
+
+func (e *ValidationError) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, err := range e.Errors {
+		errs[i] = err
+	}
+	return errs
+}
+
+// SkipValidation stops Get, Page and All from validating the query before sending it.
+// Use it to pass parameters this package does not know about yet.
+func (q *Query) SkipValidation() *Query {
+	q.skipValidation = true
+	return q
+}
+
+// Validate checks the query's parameters without making a request and returns a
+// *ValidationError listing every problem found, or nil if the query looks valid.
+// Get, Page and All call it automatically unless validation has been disabled
+// with SkipValidation or the WithoutValidation client option.
+func (q *Query) Validate() error {
+	return q.validate(q.URLBuilder)
+}
+
+var (
+	dimensionsPattern = regexp.MustCompile(`^[1-9][0-9]*x[1-9][0-9]*$`)
+	hexColorPattern   = regexp.MustCompile(`^[0-9a-fA-F]{6}$`)
+	flagMaskPattern   = regexp.MustCompile(`^[01]{3}$`)
+	dimensionsSepRe   = regexp.MustCompile(`^\s*([0-9]+)\s*[*X×:,/ ]\s*([0-9]+)\s*$`)
+)
+
+var (
+	validSortings = []SortingType{DateAdded, Relevance, Random, Views, Favorites, Toplist, Hot}
+	validOrders   = []OrderType{Descending, Ascending}
+	validRanges   = []RangeType{OneDay, ThreeDays, OneWeek, OneMonth, ThreeMonths, SixMonths, OneYear}
+)
+
+func (q *Query) validate(url *fetch.URLBuilder) error {
+	var errs []*ParamError
+	add := func(param, value, problem, suggestion string) {
+		errs = append(errs, &ParamError{Param: param, Value: value, Problem: problem, Suggestion: suggestion, Err: ErrInvalidQuery})
+	}
+
+	if value := url.GetString("atleast"); value != "" && !dimensionsPattern.MatchString(value) {
+		add("atleast", value, "must be WIDTHxHEIGHT", suggestDimensions(value))
+	}
+
+	if value := url.GetString("resolutions"); value != "" {
+		for _, res := range strings.Split(value, ",") {
+			if !dimensionsPattern.MatchString(res) {
+				add("resolutions", res, "must be WIDTHxHEIGHT", suggestDimensions(res))
+			}
+		}
+	}
+
+	if value := url.GetString("ratios"); value != "" {
+		for _, ratio := range strings.Split(value, ",") {
+			if ratio != "landscape" && ratio != "portrait" && !dimensionsPattern.MatchString(ratio) {
+				add("ratios", ratio, `must be WxH, "landscape" or "portrait"`, suggestDimensions(ratio))
+			}
+		}
+	}
+
+	if value := url.GetString("colors"); value != "" {
+		switch {
+		case !hexColorPattern.MatchString(value):
+			suggestion := ""
+			if trimmed := strings.TrimPrefix(value, "#"); hexColorPattern.MatchString(trimmed) {
+				suggestion = fmt.Sprintf("drop the '#': %q", strings.ToLower(trimmed))
+			}
+			add("colors", value, "must be a 6 digit hex color", suggestion)
+		case !Color(strings.ToLower(value)).InPalette():
+			nearest := NearestColor(Color(strings.ToLower(value)))
+			add("colors", value, "not in Wallhaven's palette", fmt.Sprintf("nearest is %q, or use Color()", nearest))
+		}
+	}
+
+	sorting := url.GetString("sorting")
+	if sorting != "" && !slices.Contains(validSortings, SortingType(sorting)) {
+		add("sorting", sorting, "unknown sorting", "use one of the SortingType constants")
+	}
+
+	if value := url.GetString("order"); value != "" && !slices.Contains(validOrders, OrderType(value)) {
+		add("order", value, "unknown order", `use Descending ("desc") or Ascending ("asc")`)
+	}
+
+	if value := url.GetString("topRange"); value != "" {
+		if !slices.Contains(validRanges, RangeType(value)) {
+			add("topRange", value, "unknown range", "use one of the RangeType constants")
+		} else if sorting != string(Toplist) {
+			add("topRange", value, "only applies to toplist sorting", "add Sort(Toplist) or use TopList()")
+		}
+	}
+
+	if value := url.GetString("purity"); value != "" {
+		switch {
+		case !flagMaskPattern.MatchString(value) || value == "000":
+			add("purity", value, "must select at least one purity level", "pass SFW, Sketchy and/or NSFW")
+		case value[2] == '1' && (q.client == nil || !q.client.hasAPIKey()):
+			add("purity", value, "NSFW results require an API key", "use NewWithAPIKey or WithAPIKey")
+		}
+	}
+
+	if value := url.GetString("categories"); value != "" && (!flagMaskPattern.MatchString(value) || value == "000") {
+		add("categories", value, "must select at least one category", "pass General, Anime and/or People")
+	}
+
+	if value := url.GetString("seed"); value != "" && ValidateSeed(value) != nil {
+		errs = append(errs, &ParamError{Param: "seed", Value: value, Problem: "must be 6 letters or digits", Err: ErrInvalidSeed})
+	}
+
+	if url.Has("page") {
+		if value := url.GetString("page"); url.GetInt("page") < 1 {
+			add("page", value, "must be a positive integer", "pages start at 1")
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return &ValidationError{Errors: errs}
+}
+
+// suggestDimensions proposes a corrected WIDTHxHEIGHT value for common typos
+// such as "1920*1080" or "16:9".
+func suggestDimensions(value string) string {
+	m := dimensionsSepRe.FindStringSubmatch(value)
+	if m == nil {
+		return ""
+	}
+	w, _ := strconv.Atoi(m[1])
+	h, _ := strconv.Atoi(m[2])
+	if w == 0 || h == 0 {
+		return ""
+	}
+	return fmt.Sprintf("try %q", fmt.Sprintf("%dx%d", w, h))
+}
@@ -0,0 +1,59 @@
+package wallhavenapi
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestClientRangeOnlyAppliesToToplist(t *testing.T) {
+	srv := newTestServer(t)
+	wh := New(WithBaseURL(srv.URL), WithoutRateLimit(), WithoutRetry()).WithRange(OneWeek)
+
+	for name, q := range map[string]*Query{
+		"Search": wh.Search("nature"),
+		"Hot":    wh.Hot(),
+	} {
+		if err := q.Validate(); err != nil {
+			t.Errorf("%s: Validate() = %v, want nil", name, err)
+		}
+		if q.Has("topRange") {
+			t.Errorf("%s: inherited topRange: %s", name, q.Raw())
+		}
+	}
+
+	if got := wh.TopList().GetString("topRange"); got != string(OneWeek) {
+		t.Errorf("TopList topRange = %q, want %q", got, OneWeek)
+	}
+	if got := wh.TopList().Range(OneDay).GetString("topRange"); got != string(OneDay) {
+		t.Errorf("TopList().Range(OneDay) topRange = %q, want %q", got, OneDay)
+	}
+
+	// A search sorted by toplist inline gets the default when it is sent.
+	page, err := wh.Search("nature").Sort(Toplist).Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page.Meta.CurrentPage != 1 {
+		t.Errorf("CurrentPage = %d, want 1", page.Meta.CurrentPage)
+	}
+
+	// A range set on the query itself is still rejected without toplist sorting.
+	var verr *ValidationError
+	if err := wh.Search("nature").Range(OneDay).Validate(); !errors.As(err, &verr) {
+		t.Errorf("inline Range without toplist: Validate() = %v, want *ValidationError", err)
+	}
+}
+
+func TestPageRejectsNonPositivePages(t *testing.T) {
+	srv := newTestServer(t)
+	q := New(WithBaseURL(srv.URL), WithoutRateLimit(), WithoutRetry()).Search("x")
+
+	for _, page := range []int{0, -1} {
+		if _, err := q.Page(page); !errors.Is(err, ErrInvalidQuery) {
+			t.Errorf("Page(%d) error = %v, want ErrInvalidQuery", page, err)
+		}
+	}
+	if _, err := q.Page(2); err != nil {
+		t.Errorf("Page(2) error = %v", err)
+	}
+}
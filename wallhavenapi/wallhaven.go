@@ -21,30 +21,71 @@ import (
 )
 
 // WallhavenAPI represents the main API client for interacting with Wallhaven.
-// It maintains URL building state and configuration for making API requests.
+// It holds the default query parameters and configuration for making API requests.
 // Use New() or NewWithApiKey() to create a new instance.
+//
+// A WallhavenAPI is immutable once constructed and safe for concurrent use.
+// The With... methods return derived clients that share the HTTP client,
+// rate limiter and circuit breaker of the client they were derived from.
 type WallhavenAPI struct {
 	urlbuilder *fetch.URLBuilder
+	fetcher    *fetch.Client
+
+	workers        int
+	skipValidation bool
+
+	// toplistRange is the default range for toplist queries, see WithRange.
+	toplistRange RangeType
+
+	blacklist Blacklist
+	safeMode  *safeMode
 }
 
 // New creates a new WallhavenAPI client for unauthenticated requests.
 // This client can search for wallpapers and access public data, but cannot
 // access NSFW content or user-specific endpoints that require authentication.
-// Use ApiKey() method to add authentication later, or use NewWithApiKey() instead.
-func New() *WallhavenAPI {
+// Use WithAPIKey() to derive an authenticated client later, or use NewWithApiKey() instead.
+// Options such as WithHTTPClient or WithTimeout customise how requests are made.
+func New(opts ...Option) *WallhavenAPI {
+	cfg := newConfig(opts)
 	return &WallhavenAPI{
-		urlbuilder: fetch.NewURL("https://wallhaven.cc/api/v1"),
+		urlbuilder: fetch.NewURL(cfg.baseURL),
+		fetcher: &fetch.Client{
+			HTTPClient: cfg.client(),
+			UserAgent:  cfg.userAgent,
+			Limiter:    cfg.limiter(),
+			Retry:      cfg.retry,
+			Breaker:    cfg.breaker,
+		},
+		workers:        cfg.workers,
+		skipValidation: cfg.skipValidation,
+		safeMode:       cfg.safeMode,
 	}
 }
 
 // NewWithApiKey creates a new WallhavenAPI client with an API key for authenticated requests.
 // The apikey parameter should be your personal Wallhaven API key obtained from your account settings.
+// The key is sent in the X-API-Key header, so it never appears in request URLs.
 // This client can access all endpoints including NSFW content and user-specific data.
 // Returns a configured WallhavenAPI instance ready for authenticated requests.
-func NewWithAPIKey(apikey string) *WallhavenAPI {
-	urlbuilder := fetch.NewURL("https://wallhaven.cc/api/v1")
-	urlbuilder.SetString("apikey", apikey)
-	return &WallhavenAPI{
-		urlbuilder: urlbuilder,
+func NewWithAPIKey(apikey string, opts ...Option) *WallhavenAPI {
+	wh := New(opts...)
+	wh.fetcher.APIKey = apikey
+	return wh
+}
+
+// hasAPIKey reports whether the client sends an API key with its requests.
+func (wh *WallhavenAPI) hasAPIKey() bool {
+	return wh.fetcher.APIKey != ""
+}
+
+// derive returns a copy of the client with its own default parameters,
+// after applying set to them. The fetcher is shared with the original.
+func (wh *WallhavenAPI) derive(set func(q *Query)) *WallhavenAPI {
+	derived := *wh
+	derived.urlbuilder = wh.urlbuilder.Clone()
+	if set != nil {
+		set(&Query{URLBuilder: derived.urlbuilder, client: &derived})
 	}
+	return &derived
 }
@@ -0,0 +1,232 @@
+package wallhavenapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"image/color"
+	"mime"
+	"strings"
+	"time"
+)
+
+// timeLayout is the format Wallhaven uses for created_at timestamps, always in UTC.
+const timeLayout = "2006-01-02 15:04:05"
+
+// MIMEType returns the wallpaper's MIME type (e.g., "image/jpeg").
+// Wallhaven reports FileType as a MIME type already; a bare extension is converted.
+func (w Wallpaper) MIMEType() string {
+	if strings.Contains(w.FileType, "/") {
+		return w.FileType
+	}
+	if w.FileType == "" {
+		return ""
+	}
+	return mime.TypeByExtension("." + normalizeFileType(w.FileType))
+}
+
+// Extension returns the file extension Wallhaven uses for the wallpaper, "jpg" or "png".
+func (w Wallpaper) Extension() string {
+	switch mediaType := w.MIMEType(); mediaType {
+	case "image/jpeg":
+		return "jpg"
+	case "":
+		return ""
+	default:
+		return strings.TrimPrefix(mediaType, "image/")
+	}
+}
+
+type wallpaperJSON struct {
+	ID         string      `json:"id"`
+	URL        string      `json:"url"`
+	ShortURL   string      `json:"short_url"`
+	Uploader   Uploader    `json:"uploader,omitzero"`
+	Views      int         `json:"views"`
+	Favorites  int         `json:"favorites"`
+	Source     string      `json:"source"`
+	Purity     string      `json:"purity"`
+	Category   string      `json:"category"`
+	DimensionX int         `json:"dimension_x"`
+	DimensionY int         `json:"dimension_y"`
+	Resolution Resolution  `json:"resolution"`
+	Ratio      wireRatio   `json:"ratio"`
+	FileSize   int         `json:"file_size"`
+	FileType   string      `json:"file_type"`
+	CreatedAt  wireTime    `json:"created_at"`
+	Colors     []wireColor `json:"colors"`
+	Path       string      `json:"path"`
+	Thumbs     Thumbnails  `json:"thumbs"`
+	Tags       []Tag       `json:"tags,omitzero"`
+}
+
+func (w *Wallpaper) UnmarshalJSON(data []byte) error {
+	var raw wallpaperJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	purity, err := ParsePurity(raw.Purity)
+	if err != nil {
+		return err
+	}
+	category, err := ParseCategory(raw.Category)
+	if err != nil {
+		return err
+	}
+
+	colors := make([]color.RGBA, len(raw.Colors))
+	for i, c := range raw.Colors {
+		colors[i] = color.RGBA(c)
+	}
+
+	*w = Wallpaper{
+		ID:         raw.ID,
+		URL:        raw.URL,
+		ShortURL:   raw.ShortURL,
+		Uploader:   raw.Uploader,
+		Views:      raw.Views,
+		Favorites:  raw.Favorites,
+		Source:     raw.Source,
+		Purity:     purity,
+		Category:   category,
+		DimensionX: raw.DimensionX,
+		DimensionY: raw.DimensionY,
+		Resolution: raw.Resolution,
+		Ratio:      AspectRatio(raw.Ratio),
+		FileSize:   raw.FileSize,
+		FileType:   raw.FileType,
+		CreatedAt:  time.Time(raw.CreatedAt),
+		Colors:     colors,
+		Path:       raw.Path,
+		Thumbs:     raw.Thumbs,
+		Tags:       raw.Tags,
+	}
+	return nil
+}
+
+func (w Wallpaper) MarshalJSON() ([]byte, error) {
+	colors := make([]wireColor, len(w.Colors))
+	for i, c := range w.Colors {
+		colors[i] = wireColor(c)
+	}
+
+	return json.Marshal(wallpaperJSON{
+		ID:         w.ID,
+		URL:        w.URL,
+		ShortURL:   w.ShortURL,
+		Uploader:   w.Uploader,
+		Views:      w.Views,
+		Favorites:  w.Favorites,
+		Source:     w.Source,
+		Purity:     w.Purity.String(),
+		Category:   w.Category.String(),
+		DimensionX: w.DimensionX,
+		DimensionY: w.DimensionY,
+		Resolution: w.Resolution,
+		Ratio:      wireRatio(w.Ratio),
+		FileSize:   w.FileSize,
+		FileType:   w.FileType,
+		CreatedAt:  wireTime(w.CreatedAt),
+		Colors:     colors,
+		Path:       w.Path,
+		Thumbs:     w.Thumbs,
+		Tags:       w.Tags,
+	})
+}
+
+type tagJSON struct {
+	ID         int      `json:"id"`
+	Name       string   `json:"name"`
+	Alias      string   `json:"alias"`
+	CategoryID int      `json:"category_id"`
+	Category   string   `json:"category"`
+	Purity     string   `json:"purity"`
+	CreatedAt  wireTime `json:"created_at"`
+}
+
+func (t *Tag) UnmarshalJSON(data []byte) error {
+	var raw tagJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	purity, err := ParsePurity(raw.Purity)
+	if err != nil {
+		return err
+	}
+	*t = Tag{
+		ID:         raw.ID,
+		Name:       raw.Name,
+		Alias:      raw.Alias,
+		CategoryID: raw.CategoryID,
+		Category:   raw.Category,
+		Purity:     purity,
+		CreatedAt:  time.Time(raw.CreatedAt),
+	}
+	return nil
+}
+
+func (t Tag) MarshalJSON() ([]byte, error) {
+	return json.Marshal(tagJSON{
+		ID:         t.ID,
+		Name:       t.Name,
+		Alias:      t.Alias,
+		CategoryID: t.CategoryID,
+		Category:   t.Category,
+		Purity:     t.Purity.String(),
+		CreatedAt:  wireTime(t.CreatedAt),
+	})
+}
+
+// wireTime encodes a time.Time in Wallhaven's created_at format.
+type wireTime time.Time
+
+func (t wireTime) MarshalText() ([]byte, error) {
+	if time.Time(t).IsZero() {
+		return []byte{}, nil
+	}
+	return []byte(time.Time(t).UTC().Format(timeLayout)), nil
+}
+
+func (t *wireTime) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*t = wireTime{}
+		return nil
+	}
+	parsed, err := time.Parse(timeLayout, string(text))
+	if err != nil {
+		return fmt.Errorf("invalid created_at %q: %w", text, err)
+	}
+	*t = wireTime(parsed)
+	return nil
+}
+
+// wireColor encodes a color.RGBA as a "#rrggbb" hex string.
+type wireColor color.RGBA
+
+func (c wireColor) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)), nil
+}
+
+func (c *wireColor) UnmarshalText(text []byte) error {
+	hex := strings.TrimPrefix(string(text), "#")
+	if !hexColorPattern.MatchString(hex) {
+		return fmt.Errorf("invalid color %q", text)
+	}
+	r, g, b, _ := Color(strings.ToLower(hex)).RGBA()
+	*c = wireColor{R: uint8(r >> 8), G: uint8(g >> 8), B: uint8(b >> 8), A: 0xff}
+	return nil
+}
+
+// wireRatio encodes an AspectRatio in the decimal form used in wallpaper data.
+type wireRatio AspectRatio
+
+func (r wireRatio) MarshalText() ([]byte, error) {
+	if AspectRatio(r).IsZero() {
+		return []byte{}, nil
+	}
+	return []byte(AspectRatio(r).Decimal()), nil
+}
+
+func (r *wireRatio) UnmarshalJSON(data []byte) error {
+	return (*AspectRatio)(r).UnmarshalJSON(data)
+}
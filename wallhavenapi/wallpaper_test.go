@@ -0,0 +1,179 @@
+package wallhavenapi
+
+import (
+	"encoding/json"
+	"image/color"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// searchListingItem is one entry from a /search response's data array; the
+// listing omits the uploader and tags.
+const searchListingItem = `{
+	"id": "94x38z",
+	"url": "https://wallhaven.cc/w/94x38z",
+	"short_url": "http://whvn.cc/94x38z",
+	"views": 6,
+	"favorites": 0,
+	"source": "",
+	"purity": "sfw",
+	"category": "anime",
+	"dimension_x": 6742,
+	"dimension_y": 3534,
+	"resolution": "6742x3534",
+	"ratio": "1.91",
+	"file_size": 5070446,
+	"file_type": "image/jpeg",
+	"created_at": "2018-10-31 01:23:10",
+	"colors": ["#000000", "#abbcda", "#424153", "#66cccc", "#333399"],
+	"path": "https://w.wallhaven.cc/full/94/wallhaven-94x38z.jpg",
+	"thumbs": {
+		"large": "https://th.wallhaven.cc/lg/94/94x38z.jpg",
+		"original": "https://th.wallhaven.cc/orig/94/94x38z.jpg",
+		"small": "https://th.wallhaven.cc/small/94/94x38z.jpg"
+	}
+}`
+
+// wallpaperInfo is the data object of a /w/{id} response.
+const wallpaperInfo = `{
+	"id": "94x38z",
+	"url": "https://wallhaven.cc/w/94x38z",
+	"short_url": "http://whvn.cc/94x38z",
+	"uploader": {
+		"username": "test-user",
+		"group": "User",
+		"avatar": {
+			"200px": "https://wallhaven.cc/images/user/avatar/200/11_3339efb2a813.png",
+			"128px": "https://wallhaven.cc/images/user/avatar/128/11_3339efb2a813.png",
+			"32px": "https://wallhaven.cc/images/user/avatar/32/11_3339efb2a813.png",
+			"20px": "https://wallhaven.cc/images/user/avatar/20/11_3339efb2a813.png"
+		}
+	},
+	"views": 12,
+	"favorites": 0,
+	"source": "",
+	"purity": "sfw",
+	"category": "anime",
+	"dimension_x": 6742,
+	"dimension_y": 3534,
+	"resolution": "6742x3534",
+	"ratio": "1.91",
+	"file_size": 5070446,
+	"file_type": "image/jpeg",
+	"created_at": "2018-10-31 01:23:10",
+	"colors": ["#000000", "#abbcda", "#424153", "#66cccc", "#333399"],
+	"path": "https://w.wallhaven.cc/full/94/wallhaven-94x38z.jpg",
+	"thumbs": {
+		"large": "https://th.wallhaven.cc/lg/94/94x38z.jpg",
+		"original": "https://th.wallhaven.cc/orig/94/94x38z.jpg",
+		"small": "https://th.wallhaven.cc/small/94/94x38z.jpg"
+	},
+	"tags": [
+		{
+			"id": 1,
+			"name": "anime",
+			"alias": "Chinese cartoons",
+			"category_id": 1,
+			"category": "Anime & Manga",
+			"purity": "sfw",
+			"created_at": "2015-01-16 02:06:45"
+		}
+	]
+}`
+
+func TestWallpaperJSONRoundTrip(t *testing.T) {
+	tests := map[string]string{
+		"search listing": searchListingItem,
+		"wallpaper info": wallpaperInfo,
+	}
+	for name, payload := range tests {
+		t.Run(name, func(t *testing.T) {
+			var w Wallpaper
+			if err := json.Unmarshal([]byte(payload), &w); err != nil {
+				t.Fatal(err)
+			}
+			encoded, err := json.Marshal(w)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var want, got map[string]any
+			if err := json.Unmarshal([]byte(payload), &want); err != nil {
+				t.Fatal(err)
+			}
+			if err := json.Unmarshal(encoded, &got); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("re-encoded wallpaper differs:\n got %s\nwant %s", encoded, payload)
+			}
+		})
+	}
+}
+
+func TestWallpaperDecodesTypedFields(t *testing.T) {
+	var w Wallpaper
+	if err := json.Unmarshal([]byte(wallpaperInfo), &w); err != nil {
+		t.Fatal(err)
+	}
+
+	if w.Purity != SFW || w.Category != Anime {
+		t.Errorf("Purity, Category = %v, %v, want SFW, Anime", w.Purity, w.Category)
+	}
+	if want := time.Date(2018, 10, 31, 1, 23, 10, 0, time.UTC); !w.CreatedAt.Equal(want) {
+		t.Errorf("CreatedAt = %s, want %s", w.CreatedAt, want)
+	}
+	if want := (color.RGBA{R: 0xab, G: 0xbc, B: 0xda, A: 0xff}); w.Colors[1] != want {
+		t.Errorf("Colors[1] = %v, want %v", w.Colors[1], want)
+	}
+	if w.Resolution != (Resolution{W: 6742, H: 3534}) {
+		t.Errorf("Resolution = %v", w.Resolution)
+	}
+	if w.MIMEType() != "image/jpeg" || w.Extension() != "jpg" {
+		t.Errorf("MIMEType, Extension = %q, %q", w.MIMEType(), w.Extension())
+	}
+	if w.Uploader.Username != "test-user" || w.Uploader.Avatar.Size32px == "" {
+		t.Errorf("Uploader = %+v", w.Uploader)
+	}
+	if len(w.Tags) != 1 || w.Tags[0].Purity != SFW || w.Tags[0].CreatedAt.IsZero() {
+		t.Errorf("Tags = %+v", w.Tags)
+	}
+}
+
+func TestWallpaperRatioDecimals(t *testing.T) {
+	tests := []struct {
+		wire string
+		want AspectRatio
+	}{
+		{"1.78", Ratio16x9},
+		{"1.6", Ratio16x10},
+		{"2.39", AspectRatio{W: 43, H: 18}}, // 3440x1440
+		{"1.91", AspectRatio{W: 191, H: 100}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.wire, func(t *testing.T) {
+			var w Wallpaper
+			if err := json.Unmarshal([]byte(`{"purity":"sfw","category":"general","ratio":"`+tt.wire+`"}`), &w); err != nil {
+				t.Fatal(err)
+			}
+			if w.Ratio != tt.want {
+				t.Errorf("Ratio = %v, want %v", w.Ratio, tt.want)
+			}
+
+			encoded, err := json.Marshal(w)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var raw struct {
+				Ratio string `json:"ratio"`
+			}
+			if err := json.Unmarshal(encoded, &raw); err != nil {
+				t.Fatal(err)
+			}
+			if raw.Ratio != tt.wire {
+				t.Errorf("re-encoded ratio = %q, want %q", raw.Ratio, tt.wire)
+			}
+		})
+	}
+}
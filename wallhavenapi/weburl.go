@@ -0,0 +1,127 @@
+package wallhavenapi
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// WebBaseURL is the address of the Wallhaven website, used by WebURL and ParseWebURL.
+const WebBaseURL = "https://wallhaven.cc"
+
+// defaultClient is used by package level helpers that need to create queries.
+var defaultClient = sync.OnceValue(func() *WallhavenAPI { return New() })
+
+// webParams are the query parameters the website shares with the API search endpoint.
+var webParams = []string{
+	"q", "categories", "purity", "sorting", "order", "topRange",
+	"atleast", "resolutions", "ratios", "colors", "seed",
+}
+
+// webSortings maps the website's listing pages onto their sorting.
+var webSortings = map[string]SortingType{
+	"toplist": Toplist,
+	"hot":     Hot,
+	"latest":  DateAdded,
+	"random":  Random,
+}
+
+// ParseWebURL is like WallhavenAPI.ParseWebURL but binds the query to a shared
+// unauthenticated client with default options.
+func ParseWebURL(rawURL string) (*Query, error) {
+	return defaultClient().ParseWebURL(rawURL)
+}
+
+// ParseWebURL turns a link to a wallhaven.cc page into a Query on this client.
+// Search, toplist, hot, latest and random listings keep their filters, tag pages
+// (/tag/37) become an exact tag search and user pages map to the user's uploads
+// (/user/name/uploads) or a favorites collection (/user/name/favorites/123).
+// Returns ErrUnsupportedURL for links that do not describe a list of wallpapers.
+func (wh *WallhavenAPI) ParseWebURL(rawURL string) (*Query, error) {
+	rawURL = strings.TrimSpace(rawURL)
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "https://" + rawURL
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedURL, err)
+	}
+	if host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www."); host != "wallhaven.cc" {
+		return nil, fmt.Errorf("%w: %q is not a wallhaven.cc link", ErrUnsupportedURL, rawURL)
+	}
+
+	var segments []string
+	if path := strings.Trim(u.Path, "/"); path != "" {
+		segments = strings.Split(path, "/")
+	}
+
+	var q *Query
+	switch {
+	case len(segments) == 0 || (len(segments) == 1 && segments[0] == "search"):
+		q = wh.newQuery("/search")
+	case len(segments) == 1 && webSortings[segments[0]] != "":
+		q = wh.newQuery("/search")
+	case len(segments) == 2 && segments[0] == "tag":
+		id, err := strconv.Atoi(segments[1])
+		if err != nil || id <= 0 {
+			return nil, fmt.Errorf("%w: invalid tag ID in %q", ErrUnsupportedURL, rawURL)
+		}
+		q = wh.newQuery("/search")
+	case len(segments) == 3 && segments[0] == "user" && segments[2] == "uploads":
+		q = wh.newQuery("/search")
+	case len(segments) == 4 && segments[0] == "user" && segments[2] == "favorites":
+		id, err := strconv.Atoi(segments[3])
+		if err != nil || id <= 0 {
+			return nil, fmt.Errorf("%w: invalid collection ID in %q", ErrUnsupportedURL, rawURL)
+		}
+		q = wh.Collection(segments[1], id)
+	default:
+		return nil, fmt.Errorf("%w: %q is not a search, listing, tag or collection page", ErrUnsupportedURL, rawURL)
+	}
+
+	params := u.Query()
+	for _, key := range webParams {
+		if value := params.Get(key); value != "" {
+			q.URLBuilder.SetString(key, value)
+		}
+	}
+
+	// The page itself decides what is listed, whatever the parameters say.
+	switch {
+	case len(segments) == 1 && webSortings[segments[0]] != "":
+		q.Sort(webSortings[segments[0]])
+		wh.applyToplistRange(q.URLBuilder)
+	case len(segments) == 2 && segments[0] == "tag":
+		q.URLBuilder.SetString("q", "id:"+segments[1])
+	case len(segments) == 3 && segments[0] == "user":
+		q.URLBuilder.SetString("q", "@"+segments[1])
+	}
+
+	return q, nil
+}
+
+// WebURL returns the wallhaven.cc link showing the same results as the query,
+// for "open in browser" buttons. It never contains credentials or page numbers.
+func (q *Query) WebURL() string {
+	params := q.params()
+	values := url.Values{}
+	for _, key := range webParams {
+		if value := params.Get(key); value != "" {
+			values.Set(key, value)
+		}
+	}
+
+	path := "/search"
+	if rest, ok := strings.CutPrefix(q.endpoint(), "/collections/"); ok {
+		if username, id, found := strings.Cut(rest, "/"); found {
+			path = fmt.Sprintf("/user/%s/favorites/%s", username, id)
+		}
+	}
+
+	if len(values) == 0 {
+		return WebBaseURL + path
+	}
+	return WebBaseURL + path + "?" + values.Encode()
+}
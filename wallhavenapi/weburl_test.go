@@ -0,0 +1,145 @@
+package wallhavenapi
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseWebURL(t *testing.T) {
+	tests := []struct {
+		url      string
+		endpoint string
+		params   map[string]string
+		webURL   string // WebURL of the parsed query
+	}{
+		{
+			url:      "https://wallhaven.cc/search?q=nature&categories=100&purity=100&sorting=toplist&topRange=1M",
+			endpoint: "/search",
+			params:   map[string]string{"q": "nature", "categories": "100", "purity": "100", "sorting": "toplist", "topRange": "1M"},
+			webURL:   WebBaseURL + "/search?categories=100&purity=100&q=nature&sorting=toplist&topRange=1M",
+		},
+		{
+			url:      "https://wallhaven.cc/toplist",
+			endpoint: "/search",
+			params:   map[string]string{"sorting": "toplist"},
+			webURL:   WebBaseURL + "/search?sorting=toplist",
+		},
+		{
+			url:      "https://wallhaven.cc/toplist?topRange=1w&sorting=random",
+			endpoint: "/search",
+			params:   map[string]string{"sorting": "toplist", "topRange": "1w"},
+			webURL:   WebBaseURL + "/search?sorting=toplist&topRange=1w",
+		},
+		{
+			url:      "https://wallhaven.cc/hot",
+			endpoint: "/search",
+			params:   map[string]string{"sorting": "hot"},
+			webURL:   WebBaseURL + "/search?sorting=hot",
+		},
+		{
+			url:      "https://wallhaven.cc/latest",
+			endpoint: "/search",
+			params:   map[string]string{"sorting": "date_added"},
+			webURL:   WebBaseURL + "/search?sorting=date_added",
+		},
+		{
+			url:      "https://wallhaven.cc/random?seed=Xk8sPq",
+			endpoint: "/search",
+			params:   map[string]string{"sorting": "random", "seed": "Xk8sPq"},
+			webURL:   WebBaseURL + "/search?seed=Xk8sPq&sorting=random",
+		},
+		{
+			url:      "https://wallhaven.cc/tag/37",
+			endpoint: "/search",
+			params:   map[string]string{"q": "id:37"},
+			webURL:   WebBaseURL + "/search?q=id%3A37",
+		},
+		{
+			url:      "https://wallhaven.cc/user/someone/uploads",
+			endpoint: "/search",
+			params:   map[string]string{"q": "@someone"},
+			webURL:   WebBaseURL + "/search?q=%40someone",
+		},
+		{
+			url:      "https://wallhaven.cc/user/someone/favorites/12?purity=110",
+			endpoint: "/collections/someone/12",
+			params:   map[string]string{"purity": "110"},
+			webURL:   WebBaseURL + "/user/someone/favorites/12?purity=110",
+		},
+		{
+			url:      "wallhaven.cc/search?q=forest&page=3&apikey=secret",
+			endpoint: "/search",
+			params:   map[string]string{"q": "forest", "page": "", "apikey": ""},
+			webURL:   WebBaseURL + "/search?q=forest",
+		},
+		{
+			url:      "https://www.wallhaven.cc/",
+			endpoint: "/search",
+			webURL:   WebBaseURL + "/search",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			q, err := ParseWebURL(tt.url)
+			if err != nil {
+				t.Fatalf("ParseWebURL(%q): %v", tt.url, err)
+			}
+			if got := q.endpoint(); got != tt.endpoint {
+				t.Errorf("endpoint = %q, want %q", got, tt.endpoint)
+			}
+			for key, want := range tt.params {
+				if got := q.URLBuilder.GetString(key); got != want {
+					t.Errorf("%s = %q, want %q", key, got, want)
+				}
+			}
+			if got := q.WebURL(); got != tt.webURL {
+				t.Errorf("WebURL = %q, want %q", got, tt.webURL)
+			}
+
+			again, err := ParseWebURL(q.WebURL())
+			if err != nil {
+				t.Fatalf("ParseWebURL(WebURL()): %v", err)
+			}
+			if again.Raw() != q.Raw() {
+				t.Errorf("WebURL did not round-trip: %q, want %q", again.Raw(), q.Raw())
+			}
+		})
+	}
+}
+
+func TestParseWebURLUnsupported(t *testing.T) {
+	for _, url := range []string{
+		"https://example.com/search?q=nature",
+		"https://wallhaven.cc/w/6k3oox",
+		"https://wallhaven.cc/tag/anime",
+		"https://wallhaven.cc/tag/0",
+		"https://wallhaven.cc/user/someone",
+		"https://wallhaven.cc/user/someone/favorites/abc",
+		"https://wallhaven.cc/settings/account",
+		"https://wallhaven.cc/%zz",
+	} {
+		if _, err := ParseWebURL(url); !errors.Is(err, ErrUnsupportedURL) {
+			t.Errorf("ParseWebURL(%q) = %v, want ErrUnsupportedURL", url, err)
+		}
+	}
+}
+
+func TestParseWebURLAppliesClientRange(t *testing.T) {
+	wh := New().WithRange(OneYear)
+
+	q, err := wh.ParseWebURL("https://wallhaven.cc/toplist")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := q.URLBuilder.GetString("topRange"); got != string(OneYear) {
+		t.Errorf("toplist topRange = %q, want %q", got, OneYear)
+	}
+
+	q, err = wh.ParseWebURL("https://wallhaven.cc/hot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := q.URLBuilder.GetString("topRange"); got != "" {
+		t.Errorf("hot topRange = %q, want none", got)
+	}
+}